@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache implements a simple on-disk blob cache for oci manifests and layers,
+// keyed by digest, that is shared across ociclient invocations.
+package cache
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// Cache caches oci blobs on disk, keyed by their digest.
+type Cache interface {
+	// Get returns a reader for the blob with the given digest, or an error if it is not cached.
+	Get(digest digest.Digest) (io.ReadCloser, error)
+	// Add stores reader's content under digest, overwriting any existing entry.
+	Add(digest digest.Digest, reader io.Reader) error
+}
+
+// Option configures a Cache created by NewCache.
+type Option interface {
+	ApplyCacheOption(*options)
+}
+
+type options struct {
+	basePath string
+}
+
+// WithBasePath sets the directory the cache stores blobs in.
+type WithBasePath string
+
+// ApplyCacheOption implements Option.
+func (p WithBasePath) ApplyCacheOption(opts *options) {
+	opts.basePath = string(p)
+}
+
+type diskCache struct {
+	basePath string
+}
+
+// NewCache creates a new on-disk Cache. The base directory (see WithBasePath) is created
+// if it does not yet exist.
+func NewCache(log interface{ Info(string, ...interface{}) }, opts ...Option) (Cache, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt.ApplyCacheOption(o)
+	}
+	if len(o.basePath) == 0 {
+		return nil, fmt.Errorf("a cache base path must be defined")
+	}
+
+	if err := os.MkdirAll(o.basePath, 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create cache directory %q: %w", o.basePath, err)
+	}
+
+	return &diskCache{basePath: o.basePath}, nil
+}
+
+func (c *diskCache) path(d digest.Digest) string {
+	return filepath.Join(c.basePath, d.Algorithm().String(), d.Encoded())
+}
+
+func (c *diskCache) Get(d digest.Digest) (io.ReadCloser, error) {
+	f, err := os.Open(c.path(d))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open cached blob %q: %w", d, err)
+	}
+	return f, nil
+}
+
+func (c *diskCache) Add(d digest.Digest, reader io.Reader) error {
+	path := c.path(d)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("unable to create cache directory for %q: %w", d, err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to create cache entry for %q: %w", d, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, reader); err != nil {
+		return fmt.Errorf("unable to write cache entry for %q: %w", d, err)
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ociclient implements a thin component-descriptor-aware client on top of
+// github.com/google/go-containerregistry, which provides the actual registry transport
+// (streamed uploads/downloads, retries).
+package ociclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	digest "github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Client pushes and pulls component descriptor oci manifests and indices.
+type Client interface {
+	// GetManifest returns the manifest stored at ref.
+	GetManifest(ctx context.Context, ref string) (*ocispecv1.Manifest, error)
+	// GetOCIArtifact returns the artifact (manifest or index) stored at ref.
+	GetOCIArtifact(ctx context.Context, ref string) (*OCIArtifact, error)
+	// Head returns the descriptor of the artifact stored at ref, without fetching its content.
+	Head(ctx context.Context, ref string) (ocispecv1.Descriptor, error)
+	// Fetch returns the content of the blob identified by desc within the repository of ref.
+	Fetch(ctx context.Context, ref string, desc ocispecv1.Descriptor) ([]byte, error)
+	// PushManifest pushes manifest under ref and returns the descriptor of the pushed
+	// manifest itself (not of its config blob), as referenced by e.g. an oci image index.
+	PushManifest(ctx context.Context, ref string, manifest *ocispecv1.Manifest) (ocispecv1.Descriptor, error)
+	// PushIndex pushes index under ref.
+	PushIndex(ctx context.Context, ref string, index *ocispecv1.Index) error
+}
+
+// OCIArtifact is either a manifest or an index, as returned by GetOCIArtifact.
+type OCIArtifact struct {
+	manifest *ocispecv1.Manifest
+	index    *ocispecv1.Index
+}
+
+// GetManifest returns the artifact's manifest, or nil if it is an index.
+func (a *OCIArtifact) GetManifest() *ocispecv1.Manifest {
+	return a.manifest
+}
+
+// GetIndex returns the artifact's index, or nil if it is a manifest.
+func (a *OCIArtifact) GetIndex() *ocispecv1.Index {
+	return a.index
+}
+
+type client struct {
+	log        logr.Logger
+	opts       *options
+	remoteOpts []remote.Option
+}
+
+// NewClient creates a new Client. The oci transport (auth, retries, plain http fallback)
+// is delegated to go-containerregistry; see Option for the available knobs.
+func NewClient(log logr.Logger, opts ...Option) (Client, error) {
+	o := newOptions(opts...)
+
+	keychain := authn.Keychain(authn.DefaultKeychain)
+	if o.keychain != nil {
+		keychain = o.keychain
+	}
+	remoteOpts := []remote.Option{remote.WithAuthFromKeychain(keychain)}
+
+	return &client{log: log, opts: o, remoteOpts: remoteOpts}, nil
+}
+
+func (c *client) parseRef(ref string) (name.Reference, error) {
+	nameOpts := []name.Option{}
+	if c.opts.allowPlainHttp {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+	return name.ParseReference(ref, nameOpts...)
+}
+
+func (c *client) GetOCIArtifact(ctx context.Context, ref string) (*OCIArtifact, error) {
+	parsed, err := c.parseRef(ref)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference %q: %w", ref, err)
+	}
+
+	desc, err := remote.Get(parsed, append(c.remoteOpts, remote.WithContext(ctx))...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get %q: %w", ref, err)
+	}
+
+	switch desc.MediaType {
+	case types.OCIImageIndex, types.DockerManifestList:
+		var index ocispecv1.Index
+		if err := json.Unmarshal(desc.Manifest, &index); err != nil {
+			return nil, fmt.Errorf("unable to decode index %q: %w", ref, err)
+		}
+		return &OCIArtifact{index: &index}, nil
+	default:
+		var manifest ocispecv1.Manifest
+		if err := json.Unmarshal(desc.Manifest, &manifest); err != nil {
+			return nil, fmt.Errorf("unable to decode manifest %q: %w", ref, err)
+		}
+		return &OCIArtifact{manifest: &manifest}, nil
+	}
+}
+
+func (c *client) GetManifest(ctx context.Context, ref string) (*ocispecv1.Manifest, error) {
+	artifact, err := c.GetOCIArtifact(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if artifact.manifest == nil {
+		return nil, fmt.Errorf("%q is an index, not a manifest", ref)
+	}
+	return artifact.manifest, nil
+}
+
+func (c *client) Head(ctx context.Context, ref string) (ocispecv1.Descriptor, error) {
+	parsed, err := c.parseRef(ref)
+	if err != nil {
+		return ocispecv1.Descriptor{}, fmt.Errorf("invalid reference %q: %w", ref, err)
+	}
+
+	desc, err := remote.Head(parsed, append(c.remoteOpts, remote.WithContext(ctx))...)
+	if err != nil {
+		if isNotFound(err) {
+			return ocispecv1.Descriptor{}, errNotFound{ref: ref}
+		}
+		return ocispecv1.Descriptor{}, fmt.Errorf("unable to head %q: %w", ref, err)
+	}
+
+	return ocispecv1.Descriptor{
+		MediaType: string(desc.MediaType),
+		Digest:    digest.NewDigestFromEncoded(digest.SHA256, desc.Digest.Hex),
+		Size:      desc.Size,
+	}, nil
+}
+
+func (c *client) Fetch(ctx context.Context, ref string, desc ocispecv1.Descriptor) ([]byte, error) {
+	repo, err := c.repository(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := toGGCRHash(desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+
+	layer, err := remote.Layer(repo.Digest(h.String()), append(c.remoteOpts, remote.WithContext(ctx))...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch blob %q from %q: %w", desc.Digest, ref, err)
+	}
+
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read blob %q: %w", desc.Digest, err)
+	}
+	defer rc.Close()
+
+	return readAll(rc)
+}
+
+func (c *client) PushManifest(ctx context.Context, ref string, manifest *ocispecv1.Manifest) (ocispecv1.Descriptor, error) {
+	if err := c.pushManifestBlobs(ctx, ref, manifest); err != nil {
+		return ocispecv1.Descriptor{}, err
+	}
+
+	parsed, err := c.parseRef(ref)
+	if err != nil {
+		return ocispecv1.Descriptor{}, fmt.Errorf("invalid reference %q: %w", ref, err)
+	}
+
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return ocispecv1.Descriptor{}, fmt.Errorf("unable to marshal manifest: %w", err)
+	}
+
+	taggable := rawTaggable{raw: raw, mediaType: types.MediaType(manifest.MediaType)}
+	if err := remote.Put(parsed, taggable, append(c.remoteOpts, remote.WithContext(ctx))...); err != nil {
+		return ocispecv1.Descriptor{}, fmt.Errorf("unable to push manifest to %q: %w", ref, err)
+	}
+
+	return ocispecv1.Descriptor{
+		MediaType: manifest.MediaType,
+		Digest:    digest.FromBytes(raw),
+		Size:      int64(len(raw)),
+	}, nil
+}
+
+// pushManifestBlobs uploads manifest's config and every layer to ref's repository via
+// PushBlob, streaming their content from the client's configured cache, before the
+// manifest itself is pushed so that it never references blobs the registry doesn't have
+// yet.
+func (c *client) pushManifestBlobs(ctx context.Context, ref string, manifest *ocispecv1.Manifest) error {
+	if c.opts.cache == nil {
+		return fmt.Errorf("unable to push blobs for %q: no cache configured", ref)
+	}
+
+	descs := append([]ocispecv1.Descriptor{manifest.Config}, manifest.Layers...)
+	for _, desc := range descs {
+		desc := desc
+		open := func() (io.ReadCloser, error) { return c.opts.cache.Get(desc.Digest) }
+
+		if err := c.PushBlob(ctx, ref, desc.Digest, desc.Size, open); err != nil {
+			return fmt.Errorf("unable to push blob %q to %q: %w", desc.Digest, ref, err)
+		}
+	}
+	return nil
+}
+
+func (c *client) PushIndex(ctx context.Context, ref string, index *ocispecv1.Index) error {
+	parsed, err := c.parseRef(ref)
+	if err != nil {
+		return fmt.Errorf("invalid reference %q: %w", ref, err)
+	}
+
+	raw, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("unable to marshal index: %w", err)
+	}
+
+	taggable := rawTaggable{raw: raw, mediaType: types.MediaType(index.MediaType)}
+	if err := remote.Put(parsed, taggable, append(c.remoteOpts, remote.WithContext(ctx))...); err != nil {
+		return fmt.Errorf("unable to push index to %q: %w", ref, err)
+	}
+	return nil
+}
+
+func (c *client) repository(ref string) (name.Repository, error) {
+	parsed, err := c.parseRef(ref)
+	if err != nil {
+		return name.Repository{}, fmt.Errorf("invalid reference %q: %w", ref, err)
+	}
+	return parsed.Context(), nil
+}
+
+// rawTaggable wraps a pre-marshalled manifest/index so it can be pushed via remote.Put
+// without being re-parsed into a full v1.Image/v1.ImageIndex.
+type rawTaggable struct {
+	raw       []byte
+	mediaType types.MediaType
+}
+
+var _ partial.WithRawManifest = rawTaggable{}
+
+func (t rawTaggable) RawManifest() ([]byte, error) {
+	return t.raw, nil
+}
+
+func (t rawTaggable) MediaType() (types.MediaType, error) {
+	return t.mediaType, nil
+}
+
+func isNotFound(err error) bool {
+	var terr *transport.Error
+	return asTransportError(err, &terr) && terr.StatusCode == 404
+}
+
+func asTransportError(err error, target **transport.Error) bool {
+	terr, ok := err.(*transport.Error)
+	if ok {
+		*target = terr
+	}
+	return ok
+}
+
+type errNotFound struct {
+	ref string
+}
+
+func (e errNotFound) Error() string {
+	return fmt.Sprintf("%q not found", e.ref)
+}
+
+// IsNotFound returns true if err indicates that the requested artifact does not exist.
+func IsNotFound(err error) bool {
+	_, ok := err.(errNotFound)
+	return ok
+}
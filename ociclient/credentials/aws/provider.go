@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package aws resolves OCI registry credentials for Amazon ECR from the
+// ambient AWS identity (e.g. an IAM role attached to the running environment).
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
+
+	"github.com/gardener/component-cli/ociclient/credentials"
+)
+
+// hostPattern matches ECR registry hosts, e.g. "123456789012.dkr.ecr.eu-central-1.amazonaws.com".
+var hostPattern = regexp.MustCompile(`^\d+\.dkr\.ecr\.[a-z0-9-]+\.amazonaws\.com$`)
+
+// newECRClient builds the ECR API client used by Resolve. Overridden in tests to inject a
+// fake ECRAPI instead of talking to a real AWS session.
+var newECRClient = func(sess *session.Session, region string) ecriface.ECRAPI {
+	return ecr.New(sess, sess.Config.WithRegion(region))
+}
+
+type provider struct{}
+
+// New creates a new credentials.Provider that authenticates against Amazon ECR
+// using the ambient AWS identity.
+func New() credentials.Provider {
+	return &provider{}
+}
+
+func (p *provider) Name() string {
+	return "aws"
+}
+
+func (p *provider) Matches(host string) bool {
+	return hostPattern.MatchString(host)
+}
+
+// Resolve exchanges the ambient AWS identity for an ECR authorization token via
+// ecr.GetAuthorizationToken. The returned token is valid for 12 hours and is base64
+// encoded as "AWS:<password>".
+func (p *provider) Resolve(ctx context.Context, host string) (credentials.AuthConfig, error) {
+	region, err := regionFromHost(host)
+	if err != nil {
+		return credentials.AuthConfig{}, err
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return credentials.AuthConfig{}, fmt.Errorf("unable to create aws session: %w", err)
+	}
+
+	client := newECRClient(sess, region)
+	out, err := client.GetAuthorizationTokenWithContext(ctx, &ecr.GetAuthorizationTokenInput{})
+	if err != nil {
+		return credentials.AuthConfig{}, fmt.Errorf("unable to get ecr authorization token: %w", err)
+	}
+	if len(out.AuthorizationData) == 0 {
+		return credentials.AuthConfig{}, fmt.Errorf("ecr returned no authorization data for region %q", region)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(*out.AuthorizationData[0].AuthorizationToken)
+	if err != nil {
+		return credentials.AuthConfig{}, fmt.Errorf("unable to decode ecr authorization token: %w", err)
+	}
+
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return credentials.AuthConfig{}, fmt.Errorf("unexpected ecr authorization token format")
+	}
+
+	return credentials.AuthConfig{
+		Username: parts[0],
+		Password: parts[1],
+	}, nil
+}
+
+func regionFromHost(host string) (string, error) {
+	parts := strings.Split(host, ".")
+	if len(parts) < 4 {
+		return "", fmt.Errorf("unable to determine aws region from registry host %q", host)
+	}
+	return parts[3], nil
+}
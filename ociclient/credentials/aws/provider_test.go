@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
+)
+
+// fakeECRClient stubs the single ecriface.ECRAPI method Resolve needs, standing in for a
+// real ECR service client backed by ambient AWS credentials.
+type fakeECRClient struct {
+	ecriface.ECRAPI
+	token string
+	err   error
+}
+
+func (f *fakeECRClient) GetAuthorizationTokenWithContext(ctx aws.Context, in *ecr.GetAuthorizationTokenInput, opts ...request.Option) (*ecr.GetAuthorizationTokenOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &ecr.GetAuthorizationTokenOutput{
+		AuthorizationData: []*ecr.AuthorizationData{
+			{AuthorizationToken: &f.token},
+		},
+	}, nil
+}
+
+func withFakeECRClient(t *testing.T, client ecriface.ECRAPI) {
+	t.Helper()
+	original := newECRClient
+	newECRClient = func(*session.Session, string) ecriface.ECRAPI { return client }
+	t.Cleanup(func() { newECRClient = original })
+}
+
+func TestProviderResolveAgainstFakeECRClient(t *testing.T) {
+	token := base64.StdEncoding.EncodeToString([]byte("AWS:super-secret-password"))
+	withFakeECRClient(t, &fakeECRClient{token: token})
+
+	p := New()
+	auth, err := p.Resolve(context.Background(), "123456789012.dkr.ecr.eu-central-1.amazonaws.com")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if auth.Username != "AWS" || auth.Password != "super-secret-password" {
+		t.Fatalf("expected decoded AWS:<password> credentials, got %+v", auth)
+	}
+}
+
+func TestProviderResolveInvalidHost(t *testing.T) {
+	p := New()
+	if _, err := p.Resolve(context.Background(), "not-an-ecr-host"); err == nil {
+		t.Fatal("expected an error for a host without a region component")
+	}
+}
+
+func TestProviderMatches(t *testing.T) {
+	p := New()
+
+	if !p.Matches("123456789012.dkr.ecr.eu-central-1.amazonaws.com") {
+		t.Errorf("expected ecr host to match")
+	}
+	if p.Matches("gcr.io") {
+		t.Errorf("expected gcr.io not to match")
+	}
+}
+
+func TestRegionFromHost(t *testing.T) {
+	region, err := regionFromHost("123456789012.dkr.ecr.eu-central-1.amazonaws.com")
+	if err != nil {
+		t.Fatalf("regionFromHost returned error: %v", err)
+	}
+	if region != "eu-central-1" {
+		t.Fatalf("expected region %q, got %q", "eu-central-1", region)
+	}
+
+	if _, err := regionFromHost("invalid"); err == nil {
+		t.Fatal("expected an error for a host with too few segments")
+	}
+}
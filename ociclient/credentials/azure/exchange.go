@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// exchangeForACRRefreshToken exchanges an AAD access token for an ACR refresh token
+// using the registry's "/oauth2/exchange" endpoint, as described in
+// https://github.com/Azure/acr/blob/main/docs/AAD-OAuth.md.
+func exchangeForACRRefreshToken(ctx context.Context, authorizer autorest.Authorizer, host string) (string, error) {
+	aadToken, err := bearerToken(authorizer)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "access_token")
+	form.Set("service", host)
+	form.Set("access_token", aadToken)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("https://%s/oauth2/exchange", host), strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("acr token exchange failed with status %q", resp.Status)
+	}
+
+	var exchanged struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&exchanged); err != nil {
+		return "", fmt.Errorf("unable to decode acr token exchange response: %w", err)
+	}
+
+	return exchanged.RefreshToken, nil
+}
+
+// bearerToken extracts the bearer token that authorizer would set on a request.
+func bearerToken(authorizer autorest.Authorizer) (string, error) {
+	req := &http.Request{Header: http.Header{}}
+	prepared, err := autorest.CreatePreparer(authorizer.WithAuthorization()).Prepare(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to prepare authorized request: %w", err)
+	}
+	return strings.TrimPrefix(prepared.Header.Get("Authorization"), "Bearer "), nil
+}
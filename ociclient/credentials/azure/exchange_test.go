@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package azure
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// fakeAuthorizer sets a fixed bearer token on every request, standing in for an MSI-backed
+// autorest.Authorizer in tests.
+type fakeAuthorizer struct {
+	token string
+}
+
+func (f fakeAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			r.Header.Set("Authorization", "Bearer "+f.token)
+			return r, nil
+		})
+	}
+}
+
+// withTrustedTLSClient installs srv's certificate into http.DefaultClient's transport for
+// the duration of fn, since exchangeForACRRefreshToken posts via http.DefaultClient.
+func withTrustedTLSClient(t *testing.T, srv *httptest.Server, fn func()) {
+	t.Helper()
+
+	original := http.DefaultClient.Transport
+	http.DefaultClient.Transport = &http.Transport{
+		TLSClientConfig: &tls.Config{
+			RootCAs: srv.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs,
+		},
+	}
+	defer func() { http.DefaultClient.Transport = original }()
+
+	fn()
+}
+
+func TestExchangeForACRRefreshToken(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth2/exchange" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("unable to parse form: %v", err)
+		}
+		if got := r.Form.Get("grant_type"); got != "access_token" {
+			t.Errorf("expected grant_type=access_token, got %q", got)
+		}
+		if got := r.Form.Get("access_token"); got != "fake-aad-token" {
+			t.Errorf("expected the aad token from the authorizer, got %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{"refresh_token": "fake-acr-refresh-token"})
+	}))
+	defer srv.Close()
+
+	host := strings.TrimPrefix(srv.URL, "https://")
+
+	withTrustedTLSClient(t, srv, func() {
+		refreshToken, err := exchangeForACRRefreshToken(context.Background(), fakeAuthorizer{token: "fake-aad-token"}, host)
+		if err != nil {
+			t.Fatalf("exchangeForACRRefreshToken returned error: %v", err)
+		}
+		if refreshToken != "fake-acr-refresh-token" {
+			t.Fatalf("expected refresh token %q, got %q", "fake-acr-refresh-token", refreshToken)
+		}
+	})
+}
+
+func TestProviderMatches(t *testing.T) {
+	p := New()
+
+	if !p.Matches("myregistry.azurecr.io") {
+		t.Errorf("expected myregistry.azurecr.io to match")
+	}
+	if p.Matches("myregistry.azurecr.cn") {
+		t.Errorf("expected myregistry.azurecr.cn not to match")
+	}
+}
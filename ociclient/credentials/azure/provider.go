@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package azure resolves OCI registry credentials for ACR from the ambient
+// Azure identity (e.g. a managed identity) by exchanging an AAD access token
+// for an ACR refresh token and then an ACR access token.
+package azure
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+
+	"github.com/gardener/component-cli/ociclient/credentials"
+)
+
+// hostPattern matches ACR hosts, e.g. "myregistry.azurecr.io".
+var hostPattern = regexp.MustCompile(`\.azurecr\.io$`)
+
+const refreshTokenUsername = "00000000-0000-0000-0000-000000000000"
+
+type provider struct{}
+
+// New creates a new credentials.Provider that authenticates against Azure Container
+// Registry using the ambient Azure identity.
+func New() credentials.Provider {
+	return &provider{}
+}
+
+func (p *provider) Name() string {
+	return "azure"
+}
+
+func (p *provider) Matches(host string) bool {
+	return hostPattern.MatchString(host)
+}
+
+// Resolve obtains an AAD access token from the ambient Azure identity and exchanges
+// it for an ACR refresh token. The refresh token is returned as the password for the
+// well-known guid username; ociclient performs the final refresh-to-access-token
+// exchange against the registry's /oauth2/token endpoint as it would for any other
+// refresh-token based login.
+func (p *provider) Resolve(ctx context.Context, host string) (credentials.AuthConfig, error) {
+	settings, err := auth.GetSettingsFromEnvironment()
+	if err != nil {
+		return credentials.AuthConfig{}, fmt.Errorf("unable to read azure settings from environment: %w", err)
+	}
+
+	authorizer, err := settings.GetMSI().Authorizer()
+	if err != nil {
+		return credentials.AuthConfig{}, fmt.Errorf("unable to get azure managed identity authorizer: %w", err)
+	}
+
+	refreshToken, err := exchangeForACRRefreshToken(ctx, authorizer, host)
+	if err != nil {
+		return credentials.AuthConfig{}, fmt.Errorf("unable to exchange aad token for acr refresh token: %w", err)
+	}
+
+	return credentials.AuthConfig{
+		Username: refreshTokenUsername,
+		Password: refreshToken,
+	}, nil
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gcp resolves OCI registry credentials for GCR/Artifact Registry from
+// the ambient GCP identity (e.g. a GCE/GKE workload identity), via the metadata server.
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"golang.org/x/oauth2/google"
+
+	"github.com/gardener/component-cli/ociclient/credentials"
+)
+
+// hostPattern matches GCR and Artifact Registry hosts, e.g. "gcr.io", "eu.gcr.io" or
+// "europe-west1-docker.pkg.dev".
+var hostPattern = regexp.MustCompile(`(^|\.)(gcr\.io|pkg\.dev)$`)
+
+const oauth2AccessTokenUsername = "oauth2accesstoken"
+
+type provider struct{}
+
+// New creates a new credentials.Provider that authenticates against GCR/Artifact Registry
+// using the ambient GCP identity obtained from the instance metadata server.
+func New() credentials.Provider {
+	return &provider{}
+}
+
+func (p *provider) Name() string {
+	return "gcp"
+}
+
+func (p *provider) Matches(host string) bool {
+	return hostPattern.MatchString(host)
+}
+
+// Resolve exchanges the ambient GCP identity for a metadata-server access token. The
+// token is used as the password for the well-known "oauth2accesstoken" username.
+func (p *provider) Resolve(ctx context.Context, host string) (credentials.AuthConfig, error) {
+	creds, err := google.FindDefaultCredentials(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil {
+		return credentials.AuthConfig{}, fmt.Errorf("unable to find ambient gcp credentials: %w", err)
+	}
+
+	token, err := creds.TokenSource.Token()
+	if err != nil {
+		return credentials.AuthConfig{}, fmt.Errorf("unable to obtain gcp access token: %w", err)
+	}
+
+	return credentials.AuthConfig{
+		Username: oauth2AccessTokenUsername,
+		Password: token.AccessToken,
+	}, nil
+}
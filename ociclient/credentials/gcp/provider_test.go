@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// withFakeGCEMetadataServer points the ambient-GCE detection used by
+// google.FindDefaultCredentials at a local httptest server for the duration of fn, so
+// provider.Resolve can be exercised without real GCP infrastructure.
+func withFakeGCEMetadataServer(t *testing.T, handler http.HandlerFunc, fn func()) {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	t.Setenv("GCE_METADATA_HOST", host)
+
+	fn()
+}
+
+func TestProviderResolveAgainstFakeMetadataServer(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Metadata-Flavor", "Google")
+		if strings.HasSuffix(r.URL.Path, "/token") {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "fake-access-token",
+				"expires_in":   3600,
+				"token_type":   "Bearer",
+			})
+			return
+		}
+		_, _ = w.Write([]byte("fake-project"))
+	}
+
+	withFakeGCEMetadataServer(t, handler, func() {
+		p := New()
+
+		auth, err := p.Resolve(context.Background(), "eu.gcr.io")
+		if err != nil {
+			t.Fatalf("Resolve returned error: %v", err)
+		}
+		if auth.Username != oauth2AccessTokenUsername {
+			t.Fatalf("expected username %q, got %q", oauth2AccessTokenUsername, auth.Username)
+		}
+		if auth.Password != "fake-access-token" {
+			t.Fatalf("expected password %q, got %q", "fake-access-token", auth.Password)
+		}
+	})
+}
+
+func TestProviderMatches(t *testing.T) {
+	p := New()
+
+	for _, host := range []string{"gcr.io", "eu.gcr.io", "europe-west1-docker.pkg.dev"} {
+		if !p.Matches(host) {
+			t.Errorf("expected %q to match", host)
+		}
+	}
+
+	if p.Matches("index.docker.io") {
+		t.Errorf("expected index.docker.io not to match")
+	}
+}
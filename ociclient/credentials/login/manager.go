@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package login selects and invokes the cloud credential provider responsible for a
+// given OCI registry host.
+package login
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+
+	"github.com/gardener/component-cli/ociclient/credentials"
+	"github.com/gardener/component-cli/ociclient/credentials/aws"
+	"github.com/gardener/component-cli/ociclient/credentials/azure"
+	"github.com/gardener/component-cli/ociclient/credentials/gcp"
+)
+
+// ProviderName identifies a supported cloud credential provider, or the special
+// values "auto" and "generic".
+type ProviderName string
+
+const (
+	// ProviderAuto selects a provider by matching the registry host against each
+	// known provider. It is the default.
+	ProviderAuto ProviderName = "auto"
+	// ProviderGeneric disables cloud credential resolution entirely.
+	ProviderGeneric ProviderName = "generic"
+	// ProviderAWS selects the aws provider.
+	ProviderAWS ProviderName = "aws"
+	// ProviderGCP selects the gcp provider.
+	ProviderGCP ProviderName = "gcp"
+	// ProviderAzure selects the azure provider.
+	ProviderAzure ProviderName = "azure"
+)
+
+// maxResolveAttempts bounds the number of retries when a provider's token exchange
+// fails, e.g. because a freshly resolved ambient token has already expired.
+const maxResolveAttempts = 3
+
+// Manager resolves the cloud credential provider responsible for a given registry host
+// and exchanges the ambient cloud identity for an authn.Keychain usable by ociclient.
+type Manager struct {
+	providers []credentials.Provider
+}
+
+// NewManager creates a new Manager with the default set of cloud providers (aws, gcp, azure).
+func NewManager() *Manager {
+	return &Manager{
+		providers: []credentials.Provider{
+			aws.New(),
+			gcp.New(),
+			azure.New(),
+		},
+	}
+}
+
+// Resolve returns a keychain that authenticates against host using the provider selected
+// by name. If name is ProviderAuto or empty, the first provider whose Matches returns
+// true for host is used. ProviderGeneric must not be passed to Resolve; callers should
+// skip cloud credential resolution entirely in that case.
+func (m *Manager) Resolve(ctx context.Context, name ProviderName, host string) (authn.Keychain, error) {
+	provider, err := m.providerFor(name, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		auth credentials.AuthConfig
+	)
+	for attempt := 1; attempt <= maxResolveAttempts; attempt++ {
+		auth, err = provider.Resolve(ctx, host)
+		if err == nil {
+			break
+		}
+		if attempt == maxResolveAttempts {
+			return nil, fmt.Errorf("unable to resolve credentials from %s provider after %d attempts: %w", provider.Name(), attempt, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(attempt) * time.Second):
+		}
+	}
+
+	return staticKeychain{authn.FromConfig(authn.AuthConfig{
+		Username: auth.Username,
+		Password: auth.Password,
+	})}, nil
+}
+
+// staticKeychain adapts a single, already-resolved authn.Authenticator as an authn.Keychain,
+// since a cloud provider resolves one set of credentials per Resolve call rather than a
+// lookup keyed by target.
+type staticKeychain struct {
+	auth authn.Authenticator
+}
+
+// Resolve implements authn.Keychain.
+func (k staticKeychain) Resolve(authn.Resource) (authn.Authenticator, error) {
+	return k.auth, nil
+}
+
+func (m *Manager) providerFor(name ProviderName, host string) (credentials.Provider, error) {
+	if name == ProviderAuto || name == "" {
+		for _, p := range m.providers {
+			if p.Matches(host) {
+				return p, nil
+			}
+		}
+		return nil, fmt.Errorf("no cloud provider matches registry host %q", host)
+	}
+
+	for _, p := range m.providers {
+		if p.Name() == string(name) {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown cloud provider %q", name)
+}
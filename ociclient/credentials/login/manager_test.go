@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package login
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gardener/component-cli/ociclient/credentials"
+)
+
+// fakeProvider is a credentials.Provider stub that matches hosts by suffix and either
+// returns a fixed AuthConfig or fails a fixed number of times before succeeding.
+type fakeProvider struct {
+	name        string
+	hostSuffix  string
+	failures    int
+	resolveCall int
+	auth        credentials.AuthConfig
+	err         error
+}
+
+func (p *fakeProvider) Name() string { return p.name }
+
+func (p *fakeProvider) Matches(host string) bool {
+	return len(p.hostSuffix) != 0 && len(host) >= len(p.hostSuffix) && host[len(host)-len(p.hostSuffix):] == p.hostSuffix
+}
+
+func (p *fakeProvider) Resolve(ctx context.Context, host string) (credentials.AuthConfig, error) {
+	p.resolveCall++
+	if p.resolveCall <= p.failures {
+		return credentials.AuthConfig{}, errors.New("transient failure")
+	}
+	if p.err != nil {
+		return credentials.AuthConfig{}, p.err
+	}
+	return p.auth, nil
+}
+
+func TestManagerResolveAutoSelectsMatchingProvider(t *testing.T) {
+	aws := &fakeProvider{name: "aws", hostSuffix: ".amazonaws.com", auth: credentials.AuthConfig{Username: "aws-user", Password: "aws-pass"}}
+	gcp := &fakeProvider{name: "gcp", hostSuffix: "gcr.io", auth: credentials.AuthConfig{Username: "gcp-user", Password: "gcp-pass"}}
+	m := &Manager{providers: []credentials.Provider{aws, gcp}}
+
+	keychain, err := m.Resolve(context.Background(), ProviderAuto, "eu.gcr.io")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+
+	auth, err := keychain.Resolve(nil)
+	if err != nil {
+		t.Fatalf("keychain.Resolve returned error: %v", err)
+	}
+	cfg, err := auth.Authorization()
+	if err != nil {
+		t.Fatalf("Authorization returned error: %v", err)
+	}
+	if cfg.Username != "gcp-user" || cfg.Password != "gcp-pass" {
+		t.Fatalf("expected gcp credentials, got %+v", cfg)
+	}
+}
+
+func TestManagerResolveExplicitProviderName(t *testing.T) {
+	aws := &fakeProvider{name: "aws", hostSuffix: ".amazonaws.com", auth: credentials.AuthConfig{Username: "aws-user", Password: "aws-pass"}}
+	azure := &fakeProvider{name: "azure", hostSuffix: ".azurecr.io", auth: credentials.AuthConfig{Username: "azure-user", Password: "azure-pass"}}
+	m := &Manager{providers: []credentials.Provider{aws, azure}}
+
+	// Explicit provider name is used even though the host would also match aws.
+	keychain, err := m.Resolve(context.Background(), ProviderAzure, "123.dkr.ecr.eu-central-1.amazonaws.com")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	auth, _ := keychain.Resolve(nil)
+	cfg, _ := auth.Authorization()
+	if cfg.Username != "azure-user" {
+		t.Fatalf("expected the explicitly named azure provider to be used, got %+v", cfg)
+	}
+}
+
+func TestManagerResolveNoMatchingProvider(t *testing.T) {
+	m := &Manager{providers: []credentials.Provider{
+		&fakeProvider{name: "aws", hostSuffix: ".amazonaws.com"},
+	}}
+
+	if _, err := m.Resolve(context.Background(), ProviderAuto, "index.docker.io"); err == nil {
+		t.Fatal("expected an error when no provider matches the host")
+	}
+}
+
+func TestManagerResolveUnknownProviderName(t *testing.T) {
+	m := &Manager{providers: []credentials.Provider{
+		&fakeProvider{name: "aws", hostSuffix: ".amazonaws.com"},
+	}}
+
+	if _, err := m.Resolve(context.Background(), "unknown", "myregistry.amazonaws.com"); err == nil {
+		t.Fatal("expected an error for an unknown provider name")
+	}
+}
+
+func TestManagerResolveRetriesOnTransientFailure(t *testing.T) {
+	aws := &fakeProvider{
+		name: "aws", hostSuffix: ".amazonaws.com", failures: maxResolveAttempts - 1,
+		auth: credentials.AuthConfig{Username: "aws-user", Password: "aws-pass"},
+	}
+	m := &Manager{providers: []credentials.Provider{aws}}
+
+	keychain, err := m.Resolve(context.Background(), ProviderAWS, "123.dkr.ecr.eu-central-1.amazonaws.com")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if aws.resolveCall != maxResolveAttempts {
+		t.Fatalf("expected %d resolve attempts, got %d", maxResolveAttempts, aws.resolveCall)
+	}
+	auth, _ := keychain.Resolve(nil)
+	if cfg, _ := auth.Authorization(); cfg.Username != "aws-user" {
+		t.Fatalf("expected credentials from the eventually successful attempt, got %+v", cfg)
+	}
+}
+
+func TestManagerResolveExhaustsRetries(t *testing.T) {
+	aws := &fakeProvider{name: "aws", hostSuffix: ".amazonaws.com", failures: maxResolveAttempts}
+	m := &Manager{providers: []credentials.Provider{aws}}
+
+	if _, err := m.Resolve(context.Background(), ProviderAWS, "123.dkr.ecr.eu-central-1.amazonaws.com"); err == nil {
+		t.Fatal("expected an error once all resolve attempts are exhausted")
+	}
+}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package credentials
+
+import "context"
+
+// AuthConfig is the resolved credential for a single registry host.
+type AuthConfig struct {
+	// Username is the username to authenticate with.
+	Username string
+	// Password is the password or token to authenticate with.
+	Password string
+}
+
+// Provider resolves OCI registry credentials from an ambient cloud identity
+// (e.g. an IAM role, a workload identity or a managed identity), without
+// requiring the user to configure a dockerconfig.json.
+type Provider interface {
+	// Name returns the human readable name of the provider, e.g. "aws", "gcp" or "azure".
+	Name() string
+	// Matches returns true if the provider is responsible for authenticating against the given registry host.
+	Matches(host string) bool
+	// Resolve exchanges the ambient cloud credentials for a bearer token usable against the registry host.
+	Resolve(ctx context.Context, host string) (AuthConfig, error)
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ociclient
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// toGGCRHash converts an opencontainers digest to the go-containerregistry hash type used
+// by the remote package.
+func toGGCRHash(d digest.Digest) (v1.Hash, error) {
+	h, err := v1.NewHash(d.String())
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("invalid digest %q: %w", d, err)
+	}
+	return h, nil
+}
+
+func readAll(r io.Reader) ([]byte, error) {
+	return ioutil.ReadAll(r)
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ociclient
+
+import (
+	"github.com/google/go-containerregistry/pkg/authn"
+
+	"github.com/gardener/component-cli/ociclient/cache"
+)
+
+type options struct {
+	cache           cache.Cache
+	keychain        authn.Keychain
+	allowPlainHttp  bool
+	knownMediaTypes map[string]struct{}
+}
+
+// Option configures a Client created by NewClient.
+type Option interface {
+	ApplyOCIOption(*options)
+}
+
+// WithCache sets the blob cache a Client reads from and writes to.
+type WithCache struct {
+	Cache cache.Cache
+}
+
+// ApplyOCIOption implements Option.
+func (o WithCache) ApplyOCIOption(opts *options) {
+	opts.cache = o.Cache
+}
+
+// WithKeyring sets the keychain a Client resolves registry credentials from. If unset,
+// the client falls back to authn.DefaultKeychain.
+type WithKeyring struct {
+	Keyring authn.Keychain
+}
+
+func (o WithKeyring) ApplyOCIOption(opts *options) {
+	opts.keychain = o.Keyring
+}
+
+// AllowPlainHttp allows the client to fall back to http if a registry does not support https.
+type AllowPlainHttp bool
+
+// ApplyOCIOption implements Option.
+func (o AllowPlainHttp) ApplyOCIOption(opts *options) {
+	opts.allowPlainHttp = bool(o)
+}
+
+// WithKnownMediaType registers an additional media type the client treats as a component
+// descriptor config/manifest/layer, e.g. for detecting which blob in a manifest is the
+// component descriptor itself.
+type WithKnownMediaType string
+
+// ApplyOCIOption implements Option.
+func (o WithKnownMediaType) ApplyOCIOption(opts *options) {
+	if opts.knownMediaTypes == nil {
+		opts.knownMediaTypes = map[string]struct{}{}
+	}
+	opts.knownMediaTypes[string(o)] = struct{}{}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt.ApplyOCIOption(o)
+	}
+	return o
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ociclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	digest "github.com/opencontainers/go-digest"
+)
+
+// maxUploadRetries bounds the number of retries for a blob upload that fails with a
+// transient (5xx or network) error.
+const maxUploadRetries = 5
+
+// PushBlob uploads a single blob of the given digest and size to the repository of ref,
+// streaming it directly to the registry instead of buffering it in memory. open is called
+// again for every retry attempt, so it must return a fresh reader over the blob's content
+// each time it is called.
+func (c *client) PushBlob(ctx context.Context, ref string, blobDigest digest.Digest, size int64, open func() (io.ReadCloser, error)) error {
+	repo, err := c.repository(ref)
+	if err != nil {
+		return err
+	}
+
+	h, err := toGGCRHash(blobDigest)
+	if err != nil {
+		return err
+	}
+
+	return retryOnTransientError(ctx, maxUploadRetries, func() error {
+		content, err := open()
+		if err != nil {
+			return fmt.Errorf("unable to open blob %q: %w", blobDigest, err)
+		}
+		defer content.Close()
+
+		layer := &streamingLayer{content: content, hash: h, size: size}
+		return remote.WriteLayer(repo, layer, append(c.remoteOpts, remote.WithContext(ctx))...)
+	})
+}
+
+// streamingLayer is a v1.Layer for a blob whose digest and size are already known, read
+// once from content as remote.WriteLayer uploads it, without ever holding its full content
+// in memory.
+type streamingLayer struct {
+	content io.Reader
+	hash    v1.Hash
+	size    int64
+}
+
+func (l *streamingLayer) Digest() (v1.Hash, error) { return l.hash, nil }
+func (l *streamingLayer) DiffID() (v1.Hash, error) { return l.hash, nil }
+func (l *streamingLayer) Size() (int64, error)     { return l.size, nil }
+func (l *streamingLayer) MediaType() (types.MediaType, error) {
+	return types.DockerLayer, nil
+}
+func (l *streamingLayer) Compressed() (io.ReadCloser, error) {
+	return io.NopCloser(l.content), nil
+}
+func (l *streamingLayer) Uncompressed() (io.ReadCloser, error) {
+	return io.NopCloser(l.content), nil
+}
+
+func retryOnTransientError(ctx context.Context, attempts int, fn func() error) error {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil || !isTransientError(err) {
+			return err
+		}
+		if attempt == attempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * time.Second):
+		}
+	}
+	return fmt.Errorf("upload failed after %d attempts: %w", attempts, err)
+}
+
+func isTransientError(err error) bool {
+	var terr *transport.Error
+	if asTransportError(err, &terr) {
+		return terr.StatusCode >= 500
+	}
+	return true
+}
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package assetsclient defines a transport-agnostic interface for publishing and
+// retrieving component descriptors and their local blobs, so that the same "push"/"pull"
+// commands can target either an oci registry or a plain object store such as GitHub
+// Releases.
+package assetsclient
+
+import (
+	"context"
+
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Manifest describes a component descriptor and the blobs it references, in the same
+// shape as an oci manifest regardless of the backend actually storing it.
+type Manifest = ocispecv1.Manifest
+
+// Client pushes and pulls component descriptor manifests to/from a backend-specific store.
+type Client interface {
+	// Push publishes manifest under ref.
+	Push(ctx context.Context, ref string, manifest *Manifest) error
+	// Pull retrieves the manifest published under ref.
+	Pull(ctx context.Context, ref string) (*Manifest, error)
+	// Fetch returns the content of the blob identified by desc within ref, as referenced
+	// by desc.Config or one of desc.Layers in the Manifest returned by Pull.
+	Fetch(ctx context.Context, ref string, desc ocispecv1.Descriptor) ([]byte, error)
+	// List returns the refs of all versions published for the component name.
+	List(ctx context.Context, name string) ([]string, error)
+}
@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package github publishes component descriptors and their local blobs as GitHub Releases,
+// as an assetsclient.Client alternative to the oci backend for air-gapped or OSS-friendly
+// distribution without running an oci registry.
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+	digest "github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/oauth2"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+
+	"github.com/gardener/component-cli/ociclient/cache"
+	"github.com/gardener/component-cli/pkg/assetsclient"
+)
+
+// RepositoryContextType is the component descriptor repository context type that selects
+// the github backend, analogous to cdv2.OCIRegistryType for the oci backend.
+const RepositoryContextType cdv2.RepositoryContextType = "GitHubRelease"
+
+// manifestAssetName is the name the component descriptor manifest is uploaded as within a
+// release, alongside one asset per blob it references.
+const manifestAssetName = "component-descriptor-manifest.json"
+
+type backend struct {
+	client *github.Client
+	owner  string
+	repo   string
+	// blobs is read from to upload the blob of each of a pushed manifest's Config/Layers as
+	// a release asset, and is not used by Pull, since pulled blobs are downloaded directly.
+	blobs cache.Cache
+}
+
+// New creates an assetsclient.Client that publishes to GitHub Releases of owner/repo,
+// authenticating with token. The content of a pushed manifest's Config/Layers blobs is
+// read from blobs.
+func New(ctx context.Context, token, owner, repo string, blobs cache.Cache) assetsclient.Client {
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	return &backend{
+		client: github.NewClient(oauth2.NewClient(ctx, ts)),
+		owner:  owner,
+		repo:   repo,
+		blobs:  blobs,
+	}
+}
+
+// blobAssetName is the name a blob of the given digest is uploaded as within a release.
+// GitHub release asset names may not contain ':', so the digest's algorithm and hex are
+// joined with '-' instead, e.g. "sha256-<hex>".
+func blobAssetName(d digest.Digest) string {
+	return fmt.Sprintf("%s-%s", d.Algorithm(), d.Encoded())
+}
+
+// Push publishes manifest as the "component-descriptor-manifest.json" asset of the release
+// tagged ref, creating the release if it does not yet exist, and uploads the blob
+// referenced by manifest's Config and each of its Layers as a release asset named after its
+// digest.
+func (b *backend) Push(ctx context.Context, ref string, manifest *assetsclient.Manifest) error {
+	release, err := b.getOrCreateRelease(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	if err := b.uploadBlobs(ctx, release.GetID(), manifest); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("unable to marshal manifest: %w", err)
+	}
+
+	if err := b.uploadAsset(ctx, release.GetID(), manifestAssetName, raw); err != nil {
+		return fmt.Errorf("unable to upload component descriptor manifest: %w", err)
+	}
+
+	return nil
+}
+
+// uploadBlobs uploads the blob referenced by manifest's Config and each of its Layers as a
+// release asset, reading their content from b.blobs.
+func (b *backend) uploadBlobs(ctx context.Context, releaseID int64, manifest *assetsclient.Manifest) error {
+	descs := append([]ocispecv1.Descriptor{manifest.Config}, manifest.Layers...)
+	for _, desc := range descs {
+		content, err := b.blobs.Get(desc.Digest)
+		if err != nil {
+			return fmt.Errorf("unable to read cached blob %q: %w", desc.Digest, err)
+		}
+
+		raw, err := ioutil.ReadAll(content)
+		content.Close()
+		if err != nil {
+			return fmt.Errorf("unable to read cached blob %q: %w", desc.Digest, err)
+		}
+
+		if err := b.uploadAsset(ctx, releaseID, blobAssetName(desc.Digest), raw); err != nil {
+			return fmt.Errorf("unable to upload blob %q: %w", desc.Digest, err)
+		}
+	}
+	return nil
+}
+
+// Pull downloads and decodes the "component-descriptor-manifest.json" asset of the release
+// tagged ref.
+func (b *backend) Pull(ctx context.Context, ref string) (*assetsclient.Manifest, error) {
+	raw, err := b.downloadAsset(ctx, ref, manifestAssetName)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &assetsclient.Manifest{}
+	if err := json.Unmarshal(raw, manifest); err != nil {
+		return nil, fmt.Errorf("unable to decode component descriptor manifest asset: %w", err)
+	}
+	return manifest, nil
+}
+
+// Fetch downloads the blob asset named after desc.Digest from the release tagged ref.
+func (b *backend) Fetch(ctx context.Context, ref string, desc ocispecv1.Descriptor) ([]byte, error) {
+	return b.downloadAsset(ctx, ref, blobAssetName(desc.Digest))
+}
+
+// downloadAsset downloads the asset named assetName from the release tagged ref.
+func (b *backend) downloadAsset(ctx context.Context, ref, assetName string) ([]byte, error) {
+	release, _, err := b.client.Repositories.GetReleaseByTag(ctx, b.owner, b.repo, ref)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get release %q: %w", ref, err)
+	}
+
+	for _, asset := range release.Assets {
+		if asset.GetName() != assetName {
+			continue
+		}
+
+		rc, _, err := b.client.Repositories.DownloadReleaseAsset(ctx, b.owner, b.repo, asset.GetID(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to download %s asset: %w", assetName, err)
+		}
+		defer rc.Close()
+
+		raw, err := ioutil.ReadAll(rc)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s asset: %w", assetName, err)
+		}
+		return raw, nil
+	}
+
+	return nil, fmt.Errorf("release %q has no %s asset", ref, assetName)
+}
+
+// List returns the tags of all releases of the repository whose tag starts with "name-",
+// the convention used when publishing a component's versions as releases.
+func (b *backend) List(ctx context.Context, name string) ([]string, error) {
+	releases, _, err := b.client.Repositories.ListReleases(ctx, b.owner, b.repo, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list releases: %w", err)
+	}
+
+	prefix := name + "-"
+	refs := make([]string, 0, len(releases))
+	for _, release := range releases {
+		if strings.HasPrefix(release.GetTagName(), prefix) {
+			refs = append(refs, release.GetTagName())
+		}
+	}
+	return refs, nil
+}
+
+func (b *backend) getOrCreateRelease(ctx context.Context, tag string) (*github.RepositoryRelease, error) {
+	release, resp, err := b.client.Repositories.GetReleaseByTag(ctx, b.owner, b.repo, tag)
+	if err == nil {
+		return release, nil
+	}
+	if resp == nil || resp.StatusCode != 404 {
+		return nil, fmt.Errorf("unable to get release %q: %w", tag, err)
+	}
+
+	release, _, err = b.client.Repositories.CreateRelease(ctx, b.owner, b.repo, &github.RepositoryRelease{
+		TagName: github.String(tag),
+		Name:    github.String(tag),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create release %q: %w", tag, err)
+	}
+	return release, nil
+}
+
+func (b *backend) uploadAsset(ctx context.Context, releaseID int64, name string, content []byte) error {
+	tmp, err := ioutil.TempFile("", "component-cli-asset-*")
+	if err != nil {
+		return err
+	}
+	defer tmp.Close()
+
+	if _, err := tmp.Write(content); err != nil {
+		return err
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		return err
+	}
+
+	_, _, err = b.client.Repositories.UploadReleaseAsset(ctx, b.owner, b.repo, releaseID, &github.UploadOptions{Name: name}, tmp)
+	return err
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package github
+
+import (
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+func TestBlobAssetName(t *testing.T) {
+	d := digest.FromString("some blob content")
+
+	got := blobAssetName(d)
+
+	want := string(d.Algorithm()) + "-" + d.Encoded()
+	if got != want {
+		t.Fatalf("blobAssetName(%q) = %q, want %q", d, got, want)
+	}
+	if containsColon(got) {
+		t.Fatalf("blobAssetName(%q) = %q, which contains ':', an invalid GitHub release asset name character", d, got)
+	}
+}
+
+func containsColon(s string) bool {
+	for _, c := range s {
+		if c == ':' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oci adapts ociclient.Client to the assetsclient.Client interface.
+package oci
+
+import (
+	"context"
+	"fmt"
+
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/gardener/component-cli/ociclient"
+	"github.com/gardener/component-cli/pkg/assetsclient"
+)
+
+type backend struct {
+	client ociclient.Client
+}
+
+// New wraps an ociclient.Client as an assetsclient.Client.
+func New(client ociclient.Client) assetsclient.Client {
+	return &backend{client: client}
+}
+
+func (b *backend) Push(ctx context.Context, ref string, manifest *assetsclient.Manifest) error {
+	_, err := b.client.PushManifest(ctx, ref, manifest)
+	return err
+}
+
+func (b *backend) Pull(ctx context.Context, ref string) (*assetsclient.Manifest, error) {
+	return b.client.GetManifest(ctx, ref)
+}
+
+func (b *backend) Fetch(ctx context.Context, ref string, desc ocispecv1.Descriptor) ([]byte, error) {
+	return b.client.Fetch(ctx, ref, desc)
+}
+
+func (b *backend) List(ctx context.Context, name string) ([]string, error) {
+	return nil, fmt.Errorf("listing component versions is not supported for the oci backend")
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cacheindex persists a small on-disk index of the last manifest seen for each
+// (registry, repository, tag), so that repeated invocations of "push" can detect that the
+// registry is already up to date and skip re-uploading, and "pull" can detect via an
+// "If-None-Match"-equivalent HEAD request that nothing changed and skip re-fetching.
+package cacheindex
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+)
+
+// indexFileName is the name of the index file within the oci cache directory.
+const indexFileName = "push-index.json"
+
+// Key identifies a single (registry, repository, tag) tuple in the index.
+type Key struct {
+	// Registry is the oci registry host, e.g. "myregistry.azurecr.io".
+	Registry string `json:"registry"`
+	// Repository is the repository path within the registry, e.g. "my-component".
+	Repository string `json:"repository"`
+	// Tag is the tag (or digest) part of the reference.
+	Tag string `json:"tag"`
+}
+
+// Entry is the cached state for a single Key.
+type Entry struct {
+	// ManifestDigest is the digest of the manifest that was last pushed for this reference.
+	ManifestDigest string `json:"manifestDigest"`
+	// BlobDigests are the digests of the blobs referenced by ManifestDigest, so that a
+	// future push can tell whether all of them are still present without re-uploading.
+	BlobDigests []string `json:"blobDigests"`
+}
+
+// Index is a concurrency-safe, file-backed map of Key to Entry, shared across parallel CLI
+// invocations via an flock-protected file in the oci cache directory.
+type Index struct {
+	path string
+	lock *flock.Flock
+}
+
+// Open returns an Index backed by a file in cacheDir. cacheDir is created if missing.
+func Open(cacheDir string) (*Index, error) {
+	if err := os.MkdirAll(cacheDir, 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create cache directory %q: %w", cacheDir, err)
+	}
+
+	path := filepath.Join(cacheDir, indexFileName)
+	return &Index{
+		path: path,
+		lock: flock.New(path + ".lock"),
+	}, nil
+}
+
+// Get returns the Entry cached for key, and whether one was found.
+func (idx *Index) Get(key Key) (Entry, bool, error) {
+	if err := idx.lock.RLock(); err != nil {
+		return Entry{}, false, fmt.Errorf("unable to lock cache index: %w", err)
+	}
+	defer idx.lock.Unlock()
+
+	entries, err := idx.read()
+	if err != nil {
+		return Entry{}, false, err
+	}
+
+	entry, ok := entries[key]
+	return entry, ok, nil
+}
+
+// Set records entry for key, persisting the update to disk.
+func (idx *Index) Set(key Key, entry Entry) error {
+	if err := idx.lock.Lock(); err != nil {
+		return fmt.Errorf("unable to lock cache index: %w", err)
+	}
+	defer idx.lock.Unlock()
+
+	entries, err := idx.read()
+	if err != nil {
+		return err
+	}
+
+	entries[key] = entry
+	return idx.write(entries)
+}
+
+func (idx *Index) read() (map[Key]Entry, error) {
+	raw, err := ioutil.ReadFile(idx.path)
+	if os.IsNotExist(err) {
+		return map[Key]Entry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cache index %q: %w", idx.path, err)
+	}
+
+	var onDisk []keyedEntry
+	if err := json.Unmarshal(raw, &onDisk); err != nil {
+		return nil, fmt.Errorf("unable to decode cache index %q: %w", idx.path, err)
+	}
+
+	entries := make(map[Key]Entry, len(onDisk))
+	for _, e := range onDisk {
+		entries[e.Key] = e.Entry
+	}
+	return entries, nil
+}
+
+func (idx *Index) write(entries map[Key]Entry) error {
+	onDisk := make([]keyedEntry, 0, len(entries))
+	for k, e := range entries {
+		onDisk = append(onDisk, keyedEntry{Key: k, Entry: e})
+	}
+
+	raw, err := json.MarshalIndent(onDisk, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode cache index: %w", err)
+	}
+
+	return ioutil.WriteFile(idx.path, raw, 0o640)
+}
+
+// keyedEntry flattens a Key/Entry pair for JSON encoding, since Key is not a valid JSON
+// object key.
+type keyedEntry struct {
+	Key   Key   `json:"key"`
+	Entry Entry `json:"entry"`
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cacheindex
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestIndexSetGetRoundTrip(t *testing.T) {
+	idx, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	key := Key{Registry: "registry.example.com", Repository: "my-component", Tag: "1.0.0"}
+	entry := Entry{ManifestDigest: "sha256:aaaa", BlobDigests: []string{"sha256:bbbb"}}
+
+	if err := idx.Set(key, entry); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+
+	got, ok, err := idx.Get(key)
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected an entry to be found")
+	}
+	if got.ManifestDigest != entry.ManifestDigest {
+		t.Fatalf("got ManifestDigest %q, want %q", got.ManifestDigest, entry.ManifestDigest)
+	}
+}
+
+func TestIndexGetMissingKey(t *testing.T) {
+	idx, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	_, ok, err := idx.Get(Key{Registry: "registry.example.com", Repository: "missing", Tag: "1.0.0"})
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if ok {
+		t.Fatal("expected no entry to be found for a key that was never set")
+	}
+}
+
+// TestIndexConcurrentSetIsSafe exercises Set from many goroutines sharing one Index,
+// simulating parallel CLI invocations against the same flock-protected file, and checks
+// that every write survives (no entries lost to a torn read-modify-write).
+func TestIndexConcurrentSetIsSafe(t *testing.T) {
+	idx, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+
+	const n = 20
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := Key{Registry: "registry.example.com", Repository: fmt.Sprintf("component-%d", i), Tag: "1.0.0"}
+			if err := idx.Set(key, Entry{ManifestDigest: fmt.Sprintf("sha256:%d", i)}); err != nil {
+				t.Errorf("Set returned error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		key := Key{Registry: "registry.example.com", Repository: fmt.Sprintf("component-%d", i), Tag: "1.0.0"}
+		got, ok, err := idx.Get(key)
+		if err != nil {
+			t.Fatalf("Get returned error: %v", err)
+		}
+		if !ok {
+			t.Fatalf("entry for %q was lost to a concurrent Set", key.Repository)
+		}
+		if got.ManifestDigest != fmt.Sprintf("sha256:%d", i) {
+			t.Fatalf("entry for %q has digest %q, want %q", key.Repository, got.ManifestDigest, fmt.Sprintf("sha256:%d", i))
+		}
+	}
+}
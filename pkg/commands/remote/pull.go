@@ -0,0 +1,273 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/codec"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	cdoci "github.com/gardener/component-spec/bindings-go/oci"
+	"github.com/go-logr/logr"
+	digest "github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/gardener/component-cli/ociclient"
+	"github.com/gardener/component-cli/ociclient/cache"
+	"github.com/gardener/component-cli/pkg/cacheindex"
+	"github.com/gardener/component-cli/pkg/logger"
+	"github.com/gardener/component-cli/pkg/utils"
+)
+
+type pullOptions struct {
+	// baseUrl is the oci registry where the component is stored.
+	baseUrl string
+	// componentName is the unique name of the component in the registry.
+	componentName string
+	// version is the component version in the oci registry.
+	version string
+	// allowPlainHttp allows the fallback to http if the oci registry does not support https
+	allowPlainHttp bool
+
+	// ref is the oci artifact uri reference of the component descriptor manifest to pull.
+	ref string
+	// cacheDir defines the oci cache directory
+	cacheDir string
+	// outputPath is the directory the component descriptor and its local blobs are written to.
+	outputPath string
+
+	// backend selects the assetsclient.Client implementation the component descriptor is
+	// pulled through (oci or github). Unlike push, this cannot be inferred from a local
+	// component descriptor's repository context, so it defaults to backendOCI.
+	backend string
+	// ghToken authenticates against the GitHub API for the github backend. Falls back to
+	// the GITHUB_TOKEN environment variable.
+	ghToken string
+
+	// requireSignature fails the pull if the component descriptor manifest has no valid
+	// signature matching publicKeyPath, or issuer and subjectRegexp.
+	requireSignature bool
+	// publicKeyPath is a path to a pem encoded public key to verify a key-based signature with.
+	publicKeyPath string
+	// issuer is the required oidc issuer of a keyless signature's signing certificate.
+	issuer string
+	// subjectRegexp matches the required subject of a keyless signature's signing certificate.
+	subjectRegexp string
+}
+
+// NewPullCommand creates a new command to pull a component descriptor and its local blobs
+// from an oci repository.
+func NewPullCommand(ctx context.Context) *cobra.Command {
+	opts := &pullOptions{}
+	cmd := &cobra.Command{
+		Use:   "pull [baseurl] [componentname] [version] [path to output directory]",
+		Args:  cobra.ExactArgs(4),
+		Short: "pulls a component descriptor and its local blobs from an oci repository",
+		Long: `
+pulls the component descriptor manifest at [baseurl]/[componentname]:[version] and writes
+the component descriptor and its local blobs to [path to output directory], in the same
+layout expected by "push".
+
+If --require-signature is set, the pull fails unless the manifest has a signature matching
+--public-key, or --issuer and --subject-regexp.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.Complete(args); err != nil {
+				fmt.Println(err.Error())
+				os.Exit(1)
+			}
+
+			if err := opts.run(ctx, logger.Log); err != nil {
+				fmt.Println(err.Error())
+				os.Exit(1)
+			}
+
+			fmt.Printf("Successfully pulled %s to %s\n", opts.ref, opts.outputPath)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *pullOptions) run(ctx context.Context, log logr.Logger) error {
+	if o.backend == backendGitHub {
+		return o.runGitHub(ctx, log)
+	}
+
+	c, err := cache.NewCache(log, cache.WithBasePath(o.cacheDir))
+	if err != nil {
+		return err
+	}
+
+	ociClient, err := ociclient.NewClient(log,
+		ociclient.WithCache{Cache: c},
+		ociclient.WithKnownMediaType(cdoci.ComponentDescriptorConfigMimeType),
+		ociclient.WithKnownMediaType(cdoci.ComponentDescriptorTarMimeType),
+		ociclient.WithKnownMediaType(cdoci.ComponentDescriptorJSONMimeType),
+		ociclient.AllowPlainHttp(o.allowPlainHttp),
+	)
+	if err != nil {
+		return err
+	}
+
+	idx, err := cacheindex.Open(o.cacheDir)
+	if err != nil {
+		return fmt.Errorf("unable to open cache index: %w", err)
+	}
+
+	manifest, cached, err := manifestFromCache(ctx, ociClient, idx, c, o.ref)
+	if err != nil {
+		return fmt.Errorf("unable to check local cache for %q: %w", o.ref, err)
+	}
+	if !cached {
+		manifest, err = ociClient.GetManifest(ctx, o.ref)
+		if err != nil {
+			return fmt.Errorf("unable to get component descriptor manifest for %q: %w", o.ref, err)
+		}
+		if err := recordManifest(idx, c, o.ref, manifest); err != nil {
+			return fmt.Errorf("unable to update cache index: %w", err)
+		}
+	}
+
+	if o.requireSignature {
+		raw, err := json.Marshal(manifest)
+		if err != nil {
+			return fmt.Errorf("unable to marshal component descriptor manifest: %w", err)
+		}
+		manifestDigest := digest.FromBytes(raw)
+
+		if err := verifySignature(ctx, ociClient, o.ref, manifestDigest, o.publicKeyPath, o.issuer, o.subjectRegexp); err != nil {
+			return fmt.Errorf("--require-signature is set but %q has no valid signature: %w", o.ref, err)
+		}
+	}
+
+	return writeComponentArchive(ctx, ociClient, o.ref, manifest, o.outputPath)
+}
+
+// blobFetcher fetches a manifest's blobs from whichever backend pulled the manifest itself,
+// and is satisfied by both ociclient.Client and assetsclient.Client.
+type blobFetcher interface {
+	Fetch(ctx context.Context, ref string, desc ocispecv1.Descriptor) ([]byte, error)
+}
+
+// writeComponentArchive decodes manifest's Config blob as a component descriptor and
+// writes it, alongside every one of manifest's Layers, to outputPath: the component
+// descriptor as ctf.ComponentDescriptorFileName, and each blob under "blobs/<algorithm>/<hex>",
+// mirroring the layout of the local oci blob cache.
+func writeComponentArchive(ctx context.Context, fetcher blobFetcher, ref string, manifest *ocispecv1.Manifest, outputPath string) error {
+	cdRaw, err := fetcher.Fetch(ctx, ref, manifest.Config)
+	if err != nil {
+		return fmt.Errorf("unable to fetch component descriptor blob: %w", err)
+	}
+
+	cd := &cdv2.ComponentDescriptor{}
+	if err := codec.Decode(cdRaw, cd); err != nil {
+		return fmt.Errorf("unable to decode component descriptor: %w", err)
+	}
+
+	if err := os.MkdirAll(outputPath, 0o750); err != nil {
+		return fmt.Errorf("unable to create output directory %q: %w", outputPath, err)
+	}
+
+	cdOut, err := codec.Encode(cd)
+	if err != nil {
+		return fmt.Errorf("unable to encode component descriptor: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputPath, ctf.ComponentDescriptorFileName), cdOut, 0o640); err != nil {
+		return fmt.Errorf("unable to write component descriptor: %w", err)
+	}
+
+	for _, layer := range manifest.Layers {
+		content, err := fetcher.Fetch(ctx, ref, layer)
+		if err != nil {
+			return fmt.Errorf("unable to fetch blob %q: %w", layer.Digest, err)
+		}
+
+		blobPath := filepath.Join(outputPath, "blobs", layer.Digest.Algorithm().String(), layer.Digest.Encoded())
+		if err := os.MkdirAll(filepath.Dir(blobPath), 0o750); err != nil {
+			return fmt.Errorf("unable to create blob directory for %q: %w", layer.Digest, err)
+		}
+		if err := os.WriteFile(blobPath, content, 0o640); err != nil {
+			return fmt.Errorf("unable to write blob %q: %w", layer.Digest, err)
+		}
+	}
+
+	return nil
+}
+
+func (o *pullOptions) Complete(args []string) error {
+	o.baseUrl = args[0]
+	o.componentName = args[1]
+	o.version = args[2]
+	o.outputPath = args[3]
+
+	var err error
+	o.cacheDir, err = utils.CacheDir()
+	if err != nil {
+		return fmt.Errorf("unable to get oci cache directory: %w", err)
+	}
+
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	if o.backend == backendGitHub {
+		o.ref, err = githubRef(cdv2.RepositoryContext{BaseURL: o.baseUrl}, o.version)
+	} else {
+		repoCtx := cdv2.NewOCIRegistryRepository(o.baseUrl, "")
+		o.ref, err = cdoci.OCIRef(*repoCtx, o.componentName, o.version)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid component reference: %w", err)
+	}
+	return nil
+}
+
+// Validate validates pull options
+func (o *pullOptions) Validate() error {
+	if len(o.baseUrl) == 0 || len(o.componentName) == 0 || len(o.version) == 0 {
+		return errors.New("baseurl, componentname and version must all be defined")
+	}
+	if len(o.outputPath) == 0 {
+		return errors.New("a path to an output directory must be defined")
+	}
+
+	switch o.backend {
+	case "", backendOCI, backendGitHub:
+	default:
+		return fmt.Errorf("unknown backend %q, must be one of %q, %q", o.backend, backendOCI, backendGitHub)
+	}
+
+	if o.requireSignature {
+		if o.backend == backendGitHub {
+			return errors.New("--require-signature is not supported with --backend=github, since push does not support signing github-published components")
+		}
+		if len(o.publicKeyPath) == 0 && len(o.issuer) == 0 && len(o.subjectRegexp) == 0 {
+			return errors.New("--require-signature is set but neither --public-key, nor --issuer and --subject-regexp are defined")
+		}
+	}
+
+	return nil
+}
+
+func (o *pullOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.allowPlainHttp, "allow-plain-http", false, "allows the fallback to http if the oci registry does not support https")
+	fs.BoolVar(&o.requireSignature, "require-signature", false, "fail unless the component descriptor manifest has a valid signature matching --public-key, or --issuer and --subject-regexp")
+	fs.StringVar(&o.publicKeyPath, "public-key", "", "path to a pem encoded public key to verify a key-based signature with")
+	fs.StringVar(&o.issuer, "issuer", "", "required oidc issuer of a keyless signature's signing certificate")
+	fs.StringVar(&o.subjectRegexp, "subject-regexp", "", "pattern the subject of a keyless signature's signing certificate must match")
+	fs.StringVar(&o.backend, "backend", "", "assets backend the component descriptor is pulled through (oci or github); defaults to oci")
+	fs.StringVar(&o.ghToken, "gh-token", "", "github token used to authenticate against the github backend; defaults to the GITHUB_TOKEN environment variable")
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/gardener/component-cli/ociclient"
+	"github.com/gardener/component-cli/ociclient/cache"
+	"github.com/gardener/component-cli/pkg/cacheindex"
+)
+
+// manifestFromCache returns the manifest last pulled or pushed for ref, and true, if the
+// cache index's record of it is confirmed up to date by an "If-None-Match"-equivalent HEAD
+// request, and its raw bytes are still present in blobs. Otherwise it returns false so the
+// caller falls back to a full GetManifest.
+func manifestFromCache(ctx context.Context, ociClient ociclient.Client, idx *cacheindex.Index, blobs cache.Cache, ref string) (*ocispecv1.Manifest, bool, error) {
+	key, err := cacheIndexKey(ref)
+	if err != nil {
+		return nil, false, err
+	}
+
+	cached, ok, err := idx.Get(key)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	remoteDesc, err := ociClient.Head(ctx, ref)
+	if err != nil {
+		if ociclient.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("unable to check remote manifest for %q: %w", ref, err)
+	}
+	if remoteDesc.Digest.String() != cached.ManifestDigest {
+		return nil, false, nil
+	}
+
+	raw, err := blobs.Get(digest.Digest(cached.ManifestDigest))
+	if err != nil {
+		return nil, false, nil
+	}
+	defer raw.Close()
+
+	manifest := &ocispecv1.Manifest{}
+	if err := json.NewDecoder(raw).Decode(manifest); err != nil {
+		return nil, false, fmt.Errorf("unable to decode cached manifest for %q: %w", ref, err)
+	}
+
+	return manifest, true, nil
+}
@@ -23,7 +23,9 @@ import (
 	"github.com/gardener/component-cli/ociclient"
 	"github.com/gardener/component-cli/ociclient/cache"
 	"github.com/gardener/component-cli/ociclient/credentials"
+	"github.com/gardener/component-cli/ociclient/credentials/login"
 	"github.com/gardener/component-cli/ociclient/credentials/secretserver"
+	"github.com/gardener/component-cli/pkg/cacheindex"
 	"github.com/gardener/component-cli/pkg/logger"
 	"github.com/gardener/component-cli/pkg/utils"
 )
@@ -50,6 +52,38 @@ type pushOptions struct {
 	registryConfigPath string
 	// ConcourseConfigPath is the path to the local concourse config file.
 	ConcourseConfigPath string
+	// provider selects the cloud credential provider used to authenticate against the
+	// oci registry with an ambient cloud identity instead of a static dockerconfig.json.
+	provider string
+
+	// platformComponentPaths are the paths to additional component archives, one per
+	// entry in osArches, that are grouped together under an oci image index.
+	platformComponentPaths []string
+	// osArches are the os/arch platforms (e.g. "linux/amd64") of platformComponentPaths,
+	// given positionally via the repeatable --os-arch flag.
+	osArches []string
+	// indexTag is the tag under which the oci image index grouping all platform
+	// archives is pushed.
+	indexTag string
+
+	// signKeyPath is the path to a PEM encoded ECDSA/ED25519 private key used to sign the
+	// pushed component descriptor manifest. The key's passphrase, if any, is read from
+	// signing.KeyPassphraseEnvVar.
+	signKeyPath string
+	// signKeyless enables keyless signing of the pushed component descriptor manifest
+	// using an OIDC identity instead of signKeyPath.
+	signKeyless bool
+	// signIdentityTokenPath is the path to a file holding a pre-obtained OIDC identity
+	// token used for keyless signing. If unset, the ambient GitHub Actions OIDC provider
+	// (ACTIONS_ID_TOKEN_REQUEST_URL/ACTIONS_ID_TOKEN_REQUEST_TOKEN) is used instead.
+	signIdentityTokenPath string
+
+	// backend selects the assetsclient.Client implementation that the component
+	// descriptor manifest is pushed through ("oci" or "github").
+	backend string
+	// ghToken authenticates against the GitHub API for the github backend. Falls back to
+	// the GITHUB_TOKEN environment variable if unset.
+	ghToken string
 }
 
 // NewPushCommand creates a new definition command to push definitions
@@ -57,18 +91,24 @@ func NewPushCommand(ctx context.Context) *cobra.Command {
 	opts := &pushOptions{}
 	cmd := &cobra.Command{
 		Use:   "push [path to component descriptor]",
-		Args:  cobra.RangeArgs(1, 4),
+		Args:  cobra.MinimumNArgs(1),
 		Short: "pushes a component archive to an oci repository",
 		Long: `
 pushes a component archive with the component descriptor and its local blobs to an oci repository.
 
-The command can be called in 2 different ways:
+The command can be called in 3 different ways:
 
 push [path to component descriptor]
 - The cli will read all necessary parameters from the component descriptor.
 
 push [baseurl] [componentname] [version] [path to component descriptor]
 - The cli will add the baseurl as repository context and validate the name and version.
+
+push --os-arch linux/amd64 --os-arch linux/arm64 [path to linux/amd64 archive] [path to linux/arm64 archive]
+- Each given path is pushed as its own manifest, annotated with the corresponding --os-arch platform,
+  and grouped under a single oci image index so that consumers can resolve a single reference to the
+  component archive matching their platform. --index-tag defines the tag of the index manifest and
+  defaults to the version of the first component archive.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := opts.Complete(args); err != nil {
@@ -91,19 +131,13 @@ push [baseurl] [componentname] [version] [path to component descriptor]
 }
 
 func (o *pushOptions) run(ctx context.Context, log logr.Logger) error {
-	cache, err := cache.NewCache(log, cache.WithBasePath(o.cacheDir))
-	if err != nil {
-		return err
-	}
-
-	archive, err := ctf.ComponentArchiveFromPath(o.componentPath)
-	if err != nil {
-		return fmt.Errorf("unable to build component archive: %w", err)
+	if o.backend == backendGitHub {
+		return o.runGitHub(ctx, log)
 	}
 
-	manifest, err := cdoci.NewManifestBuilder(cache, archive).Build(ctx)
+	cache, err := cache.NewCache(log, cache.WithBasePath(o.cacheDir))
 	if err != nil {
-		return fmt.Errorf("unable to build oci artifact for component acrchive: %w", err)
+		return err
 	}
 
 	ociOpts := []ociclient.Option{
@@ -113,12 +147,23 @@ func (o *pushOptions) run(ctx context.Context, log logr.Logger) error {
 		ociclient.WithKnownMediaType(cdoci.ComponentDescriptorJSONMimeType),
 		ociclient.AllowPlainHttp(o.allowPlainHttp),
 	}
-	if len(o.registryConfigPath) != 0 {
+	if o.provider != string(login.ProviderGeneric) {
+		repoCtx := o.cd.GetEffectiveRepositoryContext()
+		host, err := utils.ParseHostFromBaseURL(repoCtx.BaseURL)
+		if err != nil {
+			return fmt.Errorf("unable to determine oci registry host: %w", err)
+		}
+		keyring, err := login.NewManager().Resolve(ctx, login.ProviderName(o.provider), host)
+		if err != nil {
+			return fmt.Errorf("unable to resolve cloud provider credentials for %q: %w", host, err)
+		}
+		ociOpts = append(ociOpts, ociclient.WithKeyring{Keyring: keyring})
+	} else if len(o.registryConfigPath) != 0 {
 		keyring, err := credentials.CreateOCIRegistryKeyring(nil, []string{o.registryConfigPath})
 		if err != nil {
 			return fmt.Errorf("unable to create keyring for registry at %q: %w", o.registryConfigPath, err)
 		}
-		ociOpts = append(ociOpts, ociclient.WithKeyring(keyring))
+		ociOpts = append(ociOpts, ociclient.WithKeyring{Keyring: keyring})
 	} else {
 		keyring, err := secretserver.New().
 			FromPath(o.ConcourseConfigPath).
@@ -128,7 +173,7 @@ func (o *pushOptions) run(ctx context.Context, log logr.Logger) error {
 			return fmt.Errorf("unable to get credentils from secret server: %s", err.Error())
 		}
 		if keyring != nil {
-			ociOpts = append(ociOpts, ociclient.WithKeyring(keyring))
+			ociOpts = append(ociOpts, ociclient.WithKeyring{Keyring: keyring})
 		}
 	}
 
@@ -137,18 +182,70 @@ func (o *pushOptions) run(ctx context.Context, log logr.Logger) error {
 		return err
 	}
 
-	return ociClient.PushManifest(ctx, o.ref, manifest)
+	if len(o.osArches) > 0 {
+		return o.pushIndex(ctx, ociClient, cache)
+	}
+
+	archive, err := ctf.ComponentArchiveFromPath(o.componentPath)
+	if err != nil {
+		return fmt.Errorf("unable to build component archive: %w", err)
+	}
+
+	manifest, err := cdoci.NewManifestBuilder(cache, archive).Build(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to build oci artifact for component acrchive: %w", err)
+	}
+
+	idx, err := cacheindex.Open(o.cacheDir)
+	if err != nil {
+		return fmt.Errorf("unable to open push cache index: %w", err)
+	}
+
+	upToDate, err := o.skipIfUpToDate(ctx, ociClient, idx, o.ref, manifest)
+	if err != nil {
+		return fmt.Errorf("unable to check whether %q is already up to date: %w", o.ref, err)
+	}
+	if upToDate {
+		fmt.Printf("%s is already up to date\n", o.ref)
+		return nil
+	}
+
+	if _, err := ociClient.PushManifest(ctx, o.ref, manifest); err != nil {
+		return err
+	}
+
+	if err := recordManifest(idx, cache, o.ref, manifest); err != nil {
+		return fmt.Errorf("unable to update push cache index: %w", err)
+	}
+
+	if len(o.signKeyPath) != 0 || o.signKeyless {
+		if err := o.sign(ctx, ociClient, cache, manifest); err != nil {
+			return fmt.Errorf("unable to sign component descriptor manifest: %w", err)
+		}
+	}
+
+	return nil
 }
 
 func (o *pushOptions) Complete(args []string) error {
-	switch len(args) {
-	case 1:
+	if len(o.osArches) > 0 {
+		if len(args) != len(o.osArches) {
+			return fmt.Errorf("number of component archive paths (%d) must match number of --os-arch flags (%d)", len(args), len(o.osArches))
+		}
+		o.platformComponentPaths = args
 		o.componentPath = args[0]
-	case 4:
-		o.baseUrl = args[0]
-		o.componentName = args[1]
-		o.version = args[2]
-		o.componentPath = args[3]
+	} else {
+		switch len(args) {
+		case 1:
+			o.componentPath = args[0]
+		case 4:
+			o.baseUrl = args[0]
+			o.componentName = args[1]
+			o.version = args[2]
+			o.componentPath = args[3]
+		default:
+			return fmt.Errorf("expected 1 or 4 arguments, got %d", len(args))
+		}
 	}
 
 	var err error
@@ -197,7 +294,18 @@ It is expected that the given path points to a diectory that contains the compon
 	}
 
 	repoCtx := o.cd.GetEffectiveRepositoryContext()
-	o.ref, err = cdoci.OCIRef(repoCtx, o.cd.Name, o.cd.Version)
+	if len(o.backend) == 0 {
+		o.backend = backendForRepositoryContextType(repoCtx.Type)
+	}
+
+	if o.backend == backendGitHub {
+		if err := o.validateGitHubBackendFlags(); err != nil {
+			return err
+		}
+		o.ref, err = githubRef(repoCtx, o.cd.Version)
+	} else {
+		o.ref, err = cdoci.OCIRef(repoCtx, o.cd.Name, o.cd.Version)
+	}
 	if err != nil {
 		return fmt.Errorf("invalid component reference: %w", err)
 	}
@@ -214,6 +322,26 @@ func (o *pushOptions) Validate() error {
 		return errors.New("a oci cache directory must be defined")
 	}
 
+	switch login.ProviderName(o.provider) {
+	case login.ProviderAuto, login.ProviderGeneric, login.ProviderAWS, login.ProviderGCP, login.ProviderAzure:
+	default:
+		return fmt.Errorf("unknown provider %q, must be one of auto, aws, gcp, azure, generic", o.provider)
+	}
+
+	switch o.backend {
+	case "", backendOCI, backendGitHub:
+	default:
+		return fmt.Errorf("unknown backend %q, must be one of %q, %q", o.backend, backendOCI, backendGitHub)
+	}
+
+	if len(o.osArches) > 0 && len(o.baseUrl) != 0 {
+		return errors.New("--os-arch cannot be combined with the [baseurl] [componentname] [version] [path] form")
+	}
+
+	if len(o.signKeyPath) != 0 && o.signKeyless {
+		return errors.New("--sign-key and --sign-keyless are mutually exclusive")
+	}
+
 	// todo: validate references exist
 	return nil
 }
@@ -222,4 +350,12 @@ func (o *pushOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&o.allowPlainHttp, "allow-plain-http", false, "allows the fallback to http if the oci registry does not support https")
 	fs.StringVar(&o.registryConfigPath, "registry-config", "", "path to the dockerconfig.json with the oci registry authentication information")
 	fs.StringVar(&o.ConcourseConfigPath, "cc-config", "", "path to the local concourse config file")
-}
\ No newline at end of file
+	fs.StringVar(&o.provider, "provider", string(login.ProviderGeneric), "cloud provider used to resolve registry credentials from an ambient identity (auto, aws, gcp, azure, generic)")
+	fs.StringArrayVar(&o.osArches, "os-arch", nil, "os/arch platform (e.g. linux/amd64) of the component archive at the same position, grouping all given archives under an oci image index")
+	fs.StringVar(&o.indexTag, "index-tag", "", "tag under which the oci image index grouping all --os-arch component archives is pushed; defaults to the version of the first component archive")
+	fs.StringVar(&o.signKeyPath, "sign-key", "", "path to a pem encoded ecdsa/ed25519 private key used to sign the pushed component descriptor manifest")
+	fs.BoolVar(&o.signKeyless, "sign-keyless", false, "sign the pushed component descriptor manifest keylessly using an oidc identity instead of --sign-key")
+	fs.StringVar(&o.signIdentityTokenPath, "sign-identity-token-path", "", "path to a file holding a pre-obtained oidc identity token for --sign-keyless; defaults to the ambient github actions oidc provider")
+	fs.StringVar(&o.backend, "backend", "", "assets backend the component descriptor is pushed through (oci or github); defaults to the repository context type of the component descriptor")
+	fs.StringVar(&o.ghToken, "gh-token", "", "github token used to authenticate against the github backend; defaults to the GITHUB_TOKEN environment variable")
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	cdoci "github.com/gardener/component-spec/bindings-go/oci"
+	"github.com/go-logr/logr"
+
+	"github.com/gardener/component-cli/ociclient/cache"
+	"github.com/gardener/component-cli/ociclient/credentials/login"
+	ghbackend "github.com/gardener/component-cli/pkg/assetsclient/github"
+)
+
+const (
+	// backendOCI pushes the component descriptor manifest to an oci registry.
+	backendOCI = "oci"
+	// backendGitHub pushes the component descriptor manifest as a GitHub Release.
+	backendGitHub = "github"
+)
+
+// backendForRepositoryContextType returns the backend implied by a component descriptor's
+// repository context type, defaulting to backendOCI for anything but
+// ghbackend.RepositoryContextType.
+func backendForRepositoryContextType(t cdv2.RepositoryContextType) string {
+	if t == ghbackend.RepositoryContextType {
+		return backendGitHub
+	}
+	return backendOCI
+}
+
+// validateGitHubBackendFlags rejects flag combinations the github backend silently
+// ignores: it neither groups platform archives under an image index, nor signs the
+// pushed manifest, nor resolves registry credentials from a cloud identity.
+func (o *pushOptions) validateGitHubBackendFlags() error {
+	if len(o.osArches) > 0 {
+		return errors.New("--os-arch is not supported with --backend=github")
+	}
+	if len(o.signKeyPath) != 0 || o.signKeyless {
+		return errors.New("--sign-key and --sign-keyless are not supported with --backend=github")
+	}
+	if o.provider != string(login.ProviderGeneric) {
+		return errors.New("--provider is not supported with --backend=github")
+	}
+	return nil
+}
+
+// githubRef builds the "owner/repo:version" reference used by the github backend from a
+// repository context whose BaseURL is a GitHub repository url, e.g. "github.com/owner/repo".
+func githubRef(repoCtx cdv2.RepositoryContext, version string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(repoCtx.BaseURL, "https://"), "/")
+	if len(parts) < 3 {
+		return "", fmt.Errorf("expected github repository context base url of the form \"github.com/owner/repo\", got %q", repoCtx.BaseURL)
+	}
+	owner, repo := parts[len(parts)-2], parts[len(parts)-1]
+	return fmt.Sprintf("%s/%s:%s", owner, repo, version), nil
+}
+
+// runGitHub implements pushOptions.run for the github backend. It publishes the component
+// descriptor manifest and every local blob it references as release assets.
+func (o *pushOptions) runGitHub(ctx context.Context, log logr.Logger) error {
+	blobs, err := cache.NewCache(log, cache.WithBasePath(o.cacheDir))
+	if err != nil {
+		return err
+	}
+
+	archive, err := ctf.ComponentArchiveFromPath(o.componentPath)
+	if err != nil {
+		return fmt.Errorf("unable to build component archive: %w", err)
+	}
+
+	manifest, err := cdoci.NewManifestBuilder(blobs, archive).Build(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to build oci artifact for component archive: %w", err)
+	}
+
+	token := o.ghToken
+	if len(token) == 0 {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if len(token) == 0 {
+		return fmt.Errorf("a github token must be set via --gh-token or the GITHUB_TOKEN environment variable")
+	}
+
+	owner, repo, tag, err := splitGitHubRef(o.ref)
+	if err != nil {
+		return err
+	}
+
+	client := ghbackend.New(ctx, token, owner, repo, blobs)
+
+	return client.Push(ctx, tag, manifest)
+}
+
+// runGitHub implements pullOptions.run for the github backend. It downloads the component
+// descriptor manifest and every blob it references from the release assets published by
+// pushOptions.runGitHub.
+func (o *pullOptions) runGitHub(ctx context.Context, log logr.Logger) error {
+	blobs, err := cache.NewCache(log, cache.WithBasePath(o.cacheDir))
+	if err != nil {
+		return err
+	}
+
+	token := o.ghToken
+	if len(token) == 0 {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if len(token) == 0 {
+		return fmt.Errorf("a github token must be set via --gh-token or the GITHUB_TOKEN environment variable")
+	}
+
+	owner, repo, tag, err := splitGitHubRef(o.ref)
+	if err != nil {
+		return err
+	}
+
+	client := ghbackend.New(ctx, token, owner, repo, blobs)
+
+	manifest, err := client.Pull(ctx, tag)
+	if err != nil {
+		return fmt.Errorf("unable to get component descriptor manifest for %q: %w", o.ref, err)
+	}
+
+	return writeComponentArchive(ctx, client, tag, manifest, o.outputPath)
+}
+
+func splitGitHubRef(ref string) (owner, repo, tag string, err error) {
+	ownerRepo, tag, ok := cutLast(ref, ":")
+	if !ok {
+		return "", "", "", fmt.Errorf("invalid github reference %q", ref)
+	}
+	parts := strings.SplitN(ownerRepo, "/", 2)
+	if len(parts) != 2 {
+		return "", "", "", fmt.Errorf("invalid github reference %q", ref)
+	}
+	return parts[0], parts[1], tag, nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+1:], true
+}
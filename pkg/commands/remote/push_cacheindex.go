@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/gardener/component-cli/ociclient"
+	"github.com/gardener/component-cli/ociclient/cache"
+	"github.com/gardener/component-cli/pkg/cacheindex"
+)
+
+// skipIfUpToDate returns true if ref already serves manifest in the registry according to
+// both the registry itself (via a HEAD request) and the local cache index, in which case
+// the caller can skip re-pushing the manifest entirely.
+func (o *pushOptions) skipIfUpToDate(ctx context.Context, ociClient ociclient.Client, idx *cacheindex.Index, ref string, manifest *ocispecv1.Manifest) (bool, error) {
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return false, fmt.Errorf("unable to marshal manifest: %w", err)
+	}
+	manifestDigest := digest.FromBytes(raw)
+
+	key, err := cacheIndexKey(ref)
+	if err != nil {
+		return false, err
+	}
+
+	cached, ok, err := idx.Get(key)
+	if err != nil {
+		return false, err
+	}
+	if !ok || cached.ManifestDigest != manifestDigest.String() {
+		return false, nil
+	}
+
+	remoteDesc, err := ociClient.Head(ctx, ref)
+	if err != nil {
+		if ociclient.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to check remote manifest for %q: %w", ref, err)
+	}
+
+	return remoteDesc.Digest.String() == manifestDigest.String(), nil
+}
+
+// recordManifest stores manifest's digest (and its blobs' digests) for ref in the cache index,
+// so that a later, unchanged "push" of ref can be detected as already up to date, and a
+// later "pull" of ref can be served from the cache on a 304-equivalent response to its
+// If-None-Match check. manifest's raw bytes are also written to blobs, keyed by its own
+// digest, for that pull path to read back without re-fetching the manifest body.
+func recordManifest(idx *cacheindex.Index, blobs cache.Cache, ref string, manifest *ocispecv1.Manifest) error {
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("unable to marshal manifest: %w", err)
+	}
+	manifestDigest := digest.FromBytes(raw)
+
+	if err := blobs.Add(manifestDigest, bytes.NewReader(raw)); err != nil {
+		return fmt.Errorf("unable to cache manifest %q: %w", manifestDigest, err)
+	}
+
+	key, err := cacheIndexKey(ref)
+	if err != nil {
+		return err
+	}
+
+	blobDigests := make([]string, 0, len(manifest.Layers)+1)
+	blobDigests = append(blobDigests, manifest.Config.Digest.String())
+	for _, layer := range manifest.Layers {
+		blobDigests = append(blobDigests, layer.Digest.String())
+	}
+
+	return idx.Set(key, cacheindex.Entry{
+		ManifestDigest: manifestDigest.String(),
+		BlobDigests:    blobDigests,
+	})
+}
+
+// cacheIndexKey splits an oci reference of the form "registry/repository:tag" or
+// "registry/repository@digest" into a cacheindex.Key.
+func cacheIndexKey(ref string) (cacheindex.Key, error) {
+	slashIdx := strings.Index(ref, "/")
+	if slashIdx == -1 {
+		return cacheindex.Key{}, fmt.Errorf("invalid oci reference %q", ref)
+	}
+	registry := ref[:slashIdx]
+	rest := ref[slashIdx+1:]
+
+	sep := strings.LastIndexAny(rest, ":@")
+	if sep == -1 {
+		return cacheindex.Key{}, fmt.Errorf("invalid oci reference %q, missing tag or digest", ref)
+	}
+
+	return cacheindex.Key{
+		Registry:   registry,
+		Repository: rest[:sep],
+		Tag:        rest[sep+1:],
+	}, nil
+}
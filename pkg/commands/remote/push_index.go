@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gardener/component-spec/bindings-go/ctf"
+	cdoci "github.com/gardener/component-spec/bindings-go/oci"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/gardener/component-cli/ociclient"
+	"github.com/gardener/component-cli/ociclient/cache"
+)
+
+// pushIndex pushes every path in o.platformComponentPaths as its own manifest, annotated
+// with the os/arch platform at the corresponding position in o.osArches, and groups all
+// of them under a single oci image index pushed under o.ref with tag o.indexTag.
+func (o *pushOptions) pushIndex(ctx context.Context, ociClient ociclient.Client, cache cache.Cache) error {
+	index := ocispecv1.Index{
+		Versioned: ocispecv1.Versioned{SchemaVersion: 2},
+		MediaType: ocispecv1.MediaTypeImageIndex,
+	}
+
+	existing, err := ociClient.GetOCIArtifact(ctx, o.indexRef())
+	if err == nil && existing != nil && existing.GetIndex() != nil {
+		index = *existing.GetIndex()
+	}
+
+	for i, path := range o.platformComponentPaths {
+		platform, err := parsePlatform(o.osArches[i])
+		if err != nil {
+			return err
+		}
+
+		archive, err := ctf.ComponentArchiveFromPath(path)
+		if err != nil {
+			return fmt.Errorf("unable to build component archive for %s: %w", path, err)
+		}
+
+		manifest, err := cdoci.NewManifestBuilder(cache, archive).Build(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to build oci artifact for component archive %s: %w", path, err)
+		}
+
+		platformRef := fmt.Sprintf("%s-%s-%s", o.indexRef(), platform.OS, platform.Architecture)
+		manifestDesc, err := ociClient.PushManifest(ctx, platformRef, manifest)
+		if err != nil {
+			return fmt.Errorf("unable to push platform manifest for %s/%s: %w", platform.OS, platform.Architecture, err)
+		}
+
+		manifestDesc.Platform = &platform
+		index.Manifests = mergeManifest(index.Manifests, manifestDesc)
+	}
+
+	return ociClient.PushIndex(ctx, o.indexRef(), &index)
+}
+
+// indexRef returns the oci reference under which the image index is pushed, defaulting
+// the tag to the version of the component descriptor if --index-tag is not set.
+func (o *pushOptions) indexRef() string {
+	if len(o.indexTag) == 0 {
+		return o.ref
+	}
+
+	ref := o.ref
+	if idx := strings.LastIndex(ref, ":"); idx != -1 && !strings.Contains(ref[idx:], "/") {
+		ref = ref[:idx]
+	}
+	return fmt.Sprintf("%s:%s", ref, o.indexTag)
+}
+
+// mergeManifest replaces the entry for the same os/arch platform if one already exists in
+// manifests, or appends desc otherwise, so that repeated pushes for a platform update its
+// entry instead of growing the index indefinitely.
+func mergeManifest(manifests []ocispecv1.Descriptor, desc ocispecv1.Descriptor) []ocispecv1.Descriptor {
+	for i, m := range manifests {
+		if m.Platform != nil && desc.Platform != nil &&
+			m.Platform.OS == desc.Platform.OS && m.Platform.Architecture == desc.Platform.Architecture {
+			manifests[i] = desc
+			return manifests
+		}
+	}
+	return append(manifests, desc)
+}
+
+// parsePlatform parses an "os/arch" string as used by the --os-arch flag.
+func parsePlatform(osArch string) (ocispecv1.Platform, error) {
+	parts := strings.SplitN(osArch, "/", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return ocispecv1.Platform{}, fmt.Errorf("invalid --os-arch %q, expected format os/arch, e.g. linux/amd64", osArch)
+	}
+	return ocispecv1.Platform{
+		OS:           parts[0],
+		Architecture: parts[1],
+	}, nil
+}
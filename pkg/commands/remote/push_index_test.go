@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func platformDesc(os, arch string, d digest.Digest) ocispecv1.Descriptor {
+	return ocispecv1.Descriptor{
+		Digest:   d,
+		Platform: &ocispecv1.Platform{OS: os, Architecture: arch},
+	}
+}
+
+func TestMergeManifestAppendsNewPlatform(t *testing.T) {
+	existing := []ocispecv1.Descriptor{platformDesc("linux", "amd64", "sha256:aaaa")}
+
+	got := mergeManifest(existing, platformDesc("linux", "arm64", "sha256:bbbb"))
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 manifests, got %d", len(got))
+	}
+}
+
+func TestMergeManifestReplacesExistingPlatform(t *testing.T) {
+	existing := []ocispecv1.Descriptor{
+		platformDesc("linux", "amd64", "sha256:aaaa"),
+		platformDesc("linux", "arm64", "sha256:bbbb"),
+	}
+
+	updated := platformDesc("linux", "amd64", "sha256:cccc")
+	got := mergeManifest(existing, updated)
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 manifests after replace, got %d", len(got))
+	}
+	if got[0].Digest.String() != "sha256:cccc" {
+		t.Fatalf("expected linux/amd64 entry to be replaced with the new digest, got %q", got[0].Digest.String())
+	}
+	if got[1].Digest.String() != "sha256:bbbb" {
+		t.Fatalf("expected linux/arm64 entry to be untouched, got %q", got[1].Digest.String())
+	}
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	digest "github.com/opencontainers/go-digest"
+	ocispecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/gardener/component-cli/ociclient"
+	"github.com/gardener/component-cli/ociclient/cache"
+	"github.com/gardener/component-cli/pkg/signing"
+)
+
+// sign signs manifest's digest, either with the key at o.signKeyPath or keylessly via an
+// ambient OIDC identity, and pushes the resulting signature as a sibling oci manifest
+// tagged "sha256-<digest>.sig" referencing manifest by digest.
+func (o *pushOptions) sign(ctx context.Context, ociClient ociclient.Client, blobs cache.Cache, manifest *ocispecv1.Manifest) error {
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("unable to marshal component descriptor manifest: %w", err)
+	}
+	manifestDigest := digest.FromBytes(raw)
+
+	signer, err := o.signer(ctx)
+	if err != nil {
+		return err
+	}
+
+	sig, err := signer.Sign(ctx, manifestDigest.String())
+	if err != nil {
+		return fmt.Errorf("unable to sign manifest digest %q: %w", manifestDigest, err)
+	}
+
+	sigRaw, err := json.Marshal(sig)
+	if err != nil {
+		return fmt.Errorf("unable to marshal signature: %w", err)
+	}
+
+	configDigest := digest.FromString(signing.EmptyConfig)
+	if err := blobs.Add(configDigest, strings.NewReader(signing.EmptyConfig)); err != nil {
+		return fmt.Errorf("unable to cache signature artifact config blob: %w", err)
+	}
+	sigDigest := digest.FromBytes(sigRaw)
+	if err := blobs.Add(sigDigest, bytes.NewReader(sigRaw)); err != nil {
+		return fmt.Errorf("unable to cache signature blob: %w", err)
+	}
+
+	sigManifest := &ocispecv1.Manifest{
+		Versioned: ocispecv1.Versioned{SchemaVersion: 2},
+		MediaType: ocispecv1.MediaTypeImageManifest,
+		Config: ocispecv1.Descriptor{
+			MediaType: signing.EmptyConfigMediaType,
+			Digest:    configDigest,
+			Size:      int64(len(signing.EmptyConfig)),
+		},
+		Layers: []ocispecv1.Descriptor{
+			{
+				MediaType: signing.SignatureMediaType,
+				Digest:    sigDigest,
+				Size:      int64(len(sigRaw)),
+			},
+		},
+	}
+
+	_, err = ociClient.PushManifest(ctx, signatureRef(o.ref, manifestDigest), sigManifest)
+	return err
+}
+
+// signatureRef returns the oci reference of the signature artifact sibling to the
+// component descriptor manifest at ref with the given digest, replacing ref's own tag (or
+// digest) with the signature's, the same way indexRef replaces it with --index-tag.
+func signatureRef(ref string, manifestDigest digest.Digest) string {
+	if idx := strings.LastIndex(ref, ":"); idx != -1 && !strings.Contains(ref[idx:], "/") {
+		ref = ref[:idx]
+	}
+	return fmt.Sprintf("%s:%s", ref, signing.ArtifactTag(manifestDigest))
+}
+
+// signer returns the signing.Signer configured via --sign-key or --sign-keyless.
+func (o *pushOptions) signer(ctx context.Context) (signing.Signer, error) {
+	if len(o.signKeyPath) != 0 {
+		return signing.NewKeySigner(o.signKeyPath)
+	}
+
+	tokenSource, err := o.keylessTokenSource(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to obtain oidc identity token source for keyless signing: %w", err)
+	}
+
+	return signing.NewKeylessSigner(tokenSource, fulcioClient{}, rekorClient{}), nil
+}
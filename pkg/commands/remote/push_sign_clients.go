@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gardener/component-cli/pkg/signing"
+)
+
+// defaultFulcioURL and defaultRekorURL point at the public sigstore instances, mirroring
+// the defaults used by cosign for keyless signing.
+const (
+	defaultFulcioURL = "https://fulcio.sigstore.dev"
+	defaultRekorURL  = "https://rekor.sigstore.dev"
+)
+
+// fulcioClient requests short-lived signing certificates from the public Fulcio instance.
+type fulcioClient struct{}
+
+func (fulcioClient) RequestCertificate(ctx context.Context, oidcToken string, publicKeyDER []byte) (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"publicKey": base64.StdEncoding.EncodeToString(publicKeyDER),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, defaultFulcioURL+"/api/v1/signingCert", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+oidcToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("fulcio returned status %q", resp.Status)
+	}
+
+	var cert struct {
+		Certificate string `json:"certificate"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&cert); err != nil {
+		return "", fmt.Errorf("unable to decode fulcio response: %w", err)
+	}
+
+	return cert.Certificate, nil
+}
+
+// rekorClient records signatures in the public Rekor transparency log.
+type rekorClient struct{}
+
+func (rekorClient) UploadEntry(ctx context.Context, sig signing.Signature) (*signing.TransparencyLogEntry, error) {
+	body, err := json.Marshal(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, defaultRekorURL+"/api/v1/log/entries", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("rekor returned status %q", resp.Status)
+	}
+
+	var entry signing.TransparencyLogEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entry); err != nil {
+		return nil, fmt.Errorf("unable to decode rekor response: %w", err)
+	}
+
+	return &entry, nil
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gardener/component-cli/pkg/signing"
+)
+
+// keylessTokenSource returns the signing.IDTokenSource keyless signing authenticates with:
+// a file holding a pre-obtained token if --sign-identity-token-path is set, or the ambient
+// GitHub Actions OIDC provider otherwise.
+func (o *pushOptions) keylessTokenSource(ctx context.Context) (signing.IDTokenSource, error) {
+	if len(o.signIdentityTokenPath) != 0 {
+		return fileIDTokenSource{path: o.signIdentityTokenPath}, nil
+	}
+	return githubActionsIDTokenSource{}, nil
+}
+
+// fileIDTokenSource reads a pre-obtained OIDC identity token from a local file, for
+// keyless signing in environments without a supported ambient OIDC provider.
+type fileIDTokenSource struct {
+	path string
+}
+
+func (s fileIDTokenSource) IDToken(_ context.Context) (string, error) {
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read identity token from %q: %w", s.path, err)
+	}
+	return strings.TrimSpace(string(raw)), nil
+}
+
+// githubActionsIDTokenSource obtains an OIDC identity token from the ambient GitHub
+// Actions runner via the ACTIONS_ID_TOKEN_REQUEST_URL/ACTIONS_ID_TOKEN_REQUEST_TOKEN
+// environment variables set when the workflow step has "id-token: write" permission.
+type githubActionsIDTokenSource struct{}
+
+func (githubActionsIDTokenSource) IDToken(ctx context.Context) (string, error) {
+	reqURL := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL")
+	reqToken := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+	if len(reqURL) == 0 || len(reqToken) == 0 {
+		return "", fmt.Errorf("ACTIONS_ID_TOKEN_REQUEST_URL/ACTIONS_ID_TOKEN_REQUEST_TOKEN are not set; " +
+			"outside of github actions, pass --sign-identity-token-path instead")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+reqToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to request github actions oidc token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("github actions oidc token endpoint returned status %q", resp.Status)
+	}
+
+	var out struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("unable to decode github actions oidc token response: %w", err)
+	}
+	return out.Value, nil
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+
+	"github.com/gardener/component-cli/pkg/signing"
+)
+
+func TestSignatureRef(t *testing.T) {
+	manifestDigest := digest.FromString("some manifest content")
+	wantTag := signing.ArtifactTag(manifestDigest)
+
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{
+			name: "tagged ref",
+			ref:  "registry.example.com/my-component:1.2.3",
+			want: "registry.example.com/my-component:" + wantTag,
+		},
+		{
+			name: "untagged ref",
+			ref:  "registry.example.com/my-component",
+			want: "registry.example.com/my-component:" + wantTag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := signatureRef(tt.ref, manifestDigest)
+			if got != tt.want {
+				t.Fatalf("signatureRef(%q) = %q, want %q", tt.ref, got, tt.want)
+			}
+			if n := countColonsAfterLastSlash(got); n != 1 {
+				t.Fatalf("signatureRef(%q) = %q, which has %d colons after the last slash, want exactly 1", tt.ref, got, n)
+			}
+		})
+	}
+}
+
+func countColonsAfterLastSlash(ref string) int {
+	slash := -1
+	for i, c := range ref {
+		if c == '/' {
+			slash = i
+		}
+	}
+	count := 0
+	for _, c := range ref[slash+1:] {
+		if c == ':' {
+			count++
+		}
+	}
+	return count
+}
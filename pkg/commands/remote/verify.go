@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+
+	cdv2 "github.com/gardener/component-spec/bindings-go/apis/v2"
+	cdoci "github.com/gardener/component-spec/bindings-go/oci"
+	"github.com/go-logr/logr"
+	digest "github.com/opencontainers/go-digest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/gardener/component-cli/ociclient"
+	"github.com/gardener/component-cli/ociclient/cache"
+	"github.com/gardener/component-cli/pkg/logger"
+	"github.com/gardener/component-cli/pkg/signing"
+	"github.com/gardener/component-cli/pkg/utils"
+)
+
+type verifyOptions struct {
+	// baseUrl is the oci registry where the component is stored.
+	baseUrl string
+	// componentName is the unique name of the component in the registry.
+	componentName string
+	// version is the component version in the oci registry.
+	version string
+	// allowPlainHttp allows the fallback to http if the oci registry does not support https
+	allowPlainHttp bool
+
+	// ref is the oci artifact uri reference of the component descriptor manifest to verify.
+	ref string
+	// cacheDir defines the oci cache directory
+	cacheDir string
+
+	// backend selects the assets backend the component descriptor was pushed through. Only
+	// backendOCI is supported, since push does not support signing github-published
+	// components; it exists so --backend=github fails with a clear error instead of a
+	// confusing "no signature found".
+	backend string
+
+	// publicKeyPath is a path to a pem encoded public key to verify a key-based signature with.
+	publicKeyPath string
+	// issuer is the required oidc issuer of a keyless signature's signing certificate.
+	issuer string
+	// subjectRegexp matches the required subject of a keyless signature's signing certificate.
+	subjectRegexp string
+}
+
+// NewVerifyCommand creates a new command to verify the signature of a pushed component descriptor.
+func NewVerifyCommand(ctx context.Context) *cobra.Command {
+	opts := &verifyOptions{}
+	cmd := &cobra.Command{
+		Use:   "verify [baseurl] [componentname] [version]",
+		Args:  cobra.ExactArgs(3),
+		Short: "verifies the signature of a component descriptor in an oci repository",
+		Long: `
+verifies the signature of a component descriptor manifest that has been pushed with
+"push --sign-key" or "push --sign-keyless".
+
+Either --public-key, or --issuer and --subject-regexp must be given, depending on whether
+the component was signed with a key or keylessly.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.Complete(args); err != nil {
+				fmt.Println(err.Error())
+				os.Exit(1)
+			}
+
+			if err := opts.run(ctx, logger.Log); err != nil {
+				fmt.Println(err.Error())
+				os.Exit(1)
+			}
+
+			fmt.Printf("Successfully verified signature of %s\n", opts.ref)
+		},
+	}
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *verifyOptions) run(ctx context.Context, log logr.Logger) error {
+	c, err := cache.NewCache(log, cache.WithBasePath(o.cacheDir))
+	if err != nil {
+		return err
+	}
+
+	ociClient, err := ociclient.NewClient(log,
+		ociclient.WithCache{Cache: c},
+		ociclient.WithKnownMediaType(cdoci.ComponentDescriptorConfigMimeType),
+		ociclient.WithKnownMediaType(cdoci.ComponentDescriptorTarMimeType),
+		ociclient.WithKnownMediaType(cdoci.ComponentDescriptorJSONMimeType),
+		ociclient.AllowPlainHttp(o.allowPlainHttp),
+	)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := ociClient.GetManifest(ctx, o.ref)
+	if err != nil {
+		return fmt.Errorf("unable to get component descriptor manifest for %q: %w", o.ref, err)
+	}
+
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("unable to marshal component descriptor manifest: %w", err)
+	}
+	manifestDigest := digest.FromBytes(raw)
+
+	return verifySignature(ctx, ociClient, o.ref, manifestDigest, o.publicKeyPath, o.issuer, o.subjectRegexp)
+}
+
+// verifySignature fetches the signature artifact sibling to the manifest at ref with the
+// given digest, and validates it against publicKeyPath, or against issuer and
+// subjectRegexp if publicKeyPath is unset. Shared by the "verify" command and "pull
+// --require-signature".
+func verifySignature(ctx context.Context, ociClient ociclient.Client, ref string, manifestDigest digest.Digest, publicKeyPath, issuer, subjectRegexp string) error {
+	sigRef := signatureRef(ref, manifestDigest)
+	sigManifest, err := ociClient.GetManifest(ctx, sigRef)
+	if err != nil {
+		return fmt.Errorf("no signature found for %q: %w", ref, err)
+	}
+	if len(sigManifest.Layers) != 1 {
+		return fmt.Errorf("signature manifest %q is expected to have exactly one layer", sigRef)
+	}
+
+	sigRaw, err := ociClient.Fetch(ctx, sigRef, sigManifest.Layers[0])
+	if err != nil {
+		return fmt.Errorf("unable to fetch signature layer: %w", err)
+	}
+
+	var sig signing.Signature
+	if err := json.Unmarshal(sigRaw, &sig); err != nil {
+		return fmt.Errorf("unable to decode signature: %w", err)
+	}
+
+	if len(publicKeyPath) != 0 {
+		publicKey, err := os.ReadFile(publicKeyPath)
+		if err != nil {
+			return fmt.Errorf("unable to read public key %q: %w", publicKeyPath, err)
+		}
+		return signing.VerifyWithKey(sig, publicKey, manifestDigest.String())
+	}
+
+	policy := signing.IdentityPolicy{Issuer: issuer}
+	if len(subjectRegexp) != 0 {
+		policy.SubjectRegexp, err = regexp.Compile(subjectRegexp)
+		if err != nil {
+			return fmt.Errorf("invalid --subject-regexp %q: %w", subjectRegexp, err)
+		}
+	}
+	return signing.VerifyWithIdentityPolicy(sig, policy, manifestDigest.String())
+}
+
+func (o *verifyOptions) Complete(args []string) error {
+	o.baseUrl = args[0]
+	o.componentName = args[1]
+	o.version = args[2]
+
+	var err error
+	o.cacheDir, err = utils.CacheDir()
+	if err != nil {
+		return fmt.Errorf("unable to get oci cache directory: %w", err)
+	}
+
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	repoCtx := cdv2.NewOCIRegistryRepository(o.baseUrl, "")
+	o.ref, err = cdoci.OCIRef(*repoCtx, o.componentName, o.version)
+	if err != nil {
+		return fmt.Errorf("invalid component reference: %w", err)
+	}
+	return nil
+}
+
+// Validate validates verify options
+func (o *verifyOptions) Validate() error {
+	if len(o.baseUrl) == 0 || len(o.componentName) == 0 || len(o.version) == 0 {
+		return errors.New("baseurl, componentname and version must all be defined")
+	}
+
+	if len(o.publicKeyPath) == 0 && len(o.issuer) == 0 && len(o.subjectRegexp) == 0 {
+		return errors.New("either --public-key, or --issuer and --subject-regexp must be defined")
+	}
+
+	switch o.backend {
+	case "", backendOCI:
+	case backendGitHub:
+		return errors.New("signature verification is not supported with --backend=github, since push does not support signing github-published components")
+	default:
+		return fmt.Errorf("unknown backend %q, must be one of %q, %q", o.backend, backendOCI, backendGitHub)
+	}
+
+	return nil
+}
+
+func (o *verifyOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.allowPlainHttp, "allow-plain-http", false, "allows the fallback to http if the oci registry does not support https")
+	fs.StringVar(&o.publicKeyPath, "public-key", "", "path to a pem encoded public key to verify a key-based signature with")
+	fs.StringVar(&o.issuer, "issuer", "", "required oidc issuer of a keyless signature's signing certificate")
+	fs.StringVar(&o.subjectRegexp, "subject-regexp", "", "pattern the subject of a keyless signature's signing certificate must match")
+	fs.StringVar(&o.backend, "backend", "", "assets backend the component descriptor was pushed through; only oci is supported")
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// KeyPassphraseEnvVar is the environment variable read for the passphrase protecting an
+// encrypted PEM private key given via --sign-key.
+const KeyPassphraseEnvVar = "COMPONENT_CLI_SIGN_KEY_PASSPHRASE"
+
+// keySigner signs manifest digests with a local ECDSA or ED25519 private key.
+type keySigner struct {
+	algorithm string
+	key       crypto.Signer
+}
+
+// NewKeySigner loads a PEM encoded ECDSA or ED25519 private key from keyPath, decrypting
+// it with the passphrase from KeyPassphraseEnvVar if it is encrypted, and returns a Signer
+// using that key.
+func NewKeySigner(keyPath string) (Signer, error) {
+	raw, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read signing key %q: %w", keyPath, err)
+	}
+
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("unable to decode pem block from %q", keyPath)
+	}
+
+	der := block.Bytes
+	if x509.IsEncryptedPEMBlock(block) { //nolint:staticcheck // dockerconfig-style encrypted PEM keys are still in use.
+		passphrase := os.Getenv(KeyPassphraseEnvVar)
+		if len(passphrase) == 0 {
+			return nil, fmt.Errorf("key %q is encrypted but %s is not set", keyPath, KeyPassphraseEnvVar)
+		}
+		der, err = x509.DecryptPEMBlock(block, []byte(passphrase)) //nolint:staticcheck
+		if err != nil {
+			return nil, fmt.Errorf("unable to decrypt signing key %q: %w", keyPath, err)
+		}
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse signing key %q: %w", keyPath, err)
+	}
+
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return &keySigner{algorithm: "ecdsa-" + k.Curve.Params().Name, key: k}, nil
+	case ed25519.PrivateKey:
+		return &keySigner{algorithm: "ed25519", key: k}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T, expected ecdsa or ed25519", key)
+	}
+}
+
+func (s *keySigner) Algorithm() string {
+	return s.algorithm
+}
+
+func (s *keySigner) Sign(_ context.Context, manifestDigest string) (Signature, error) {
+	sig, err := s.key.Sign(rand.Reader, []byte(manifestDigest), crypto.Hash(0))
+	if err != nil {
+		return Signature{}, fmt.Errorf("unable to sign manifest digest: %w", err)
+	}
+
+	return Signature{
+		ManifestDigest: manifestDigest,
+		Algorithm:      s.algorithm,
+		Signature:      base64.StdEncoding.EncodeToString(sig),
+	}, nil
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeKeyPair writes priv, PKCS8/PEM encoded, to a key file in a temp directory and
+// returns its path alongside the PEM encoding of its public half.
+func writeKeyPair(t *testing.T, pub crypto.PublicKey, priv crypto.PrivateKey) (keyPath string, publicKeyPEM []byte) {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("unable to marshal private key: %v", err)
+	}
+	keyPath = filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("unable to write private key: %v", err)
+	}
+
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("unable to marshal public key: %v", err)
+	}
+	publicKeyPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+
+	return keyPath, publicKeyPEM
+}
+
+func TestKeySignerSignVerifyRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		gen  func(t *testing.T) (keyPath string, publicKeyPEM []byte)
+	}{
+		{
+			name: "ecdsa",
+			gen: func(t *testing.T) (string, []byte) {
+				priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				if err != nil {
+					t.Fatalf("unable to generate ecdsa key: %v", err)
+				}
+				return writeKeyPair(t, priv.Public(), priv)
+			},
+		},
+		{
+			name: "ed25519",
+			gen: func(t *testing.T) (string, []byte) {
+				pub, priv, err := ed25519.GenerateKey(rand.Reader)
+				if err != nil {
+					t.Fatalf("unable to generate ed25519 key: %v", err)
+				}
+				return writeKeyPair(t, pub, priv)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyPath, publicKeyPEM := tt.gen(t)
+
+			signer, err := NewKeySigner(keyPath)
+			if err != nil {
+				t.Fatalf("NewKeySigner returned error: %v", err)
+			}
+
+			const manifestDigest = "sha256:deadbeef"
+			sig, err := signer.Sign(context.Background(), manifestDigest)
+			if err != nil {
+				t.Fatalf("Sign returned error: %v", err)
+			}
+
+			if err := VerifyWithKey(sig, publicKeyPEM, manifestDigest); err != nil {
+				t.Fatalf("VerifyWithKey returned error for a valid signature: %v", err)
+			}
+
+			if err := VerifyWithKey(sig, publicKeyPEM, "sha256:othervalue"); err == nil {
+				t.Fatal("expected VerifyWithKey to fail for a mismatched digest")
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+)
+
+const keylessAlgorithm = "fulcio-keyless"
+
+// IDTokenSource obtains a short-lived OIDC identity token (a signed JWT) that asserts the
+// signer's identity, e.g. sourced from an ambient CI OIDC provider such as GitHub Actions,
+// or from a pre-obtained token supplied by the caller.
+type IDTokenSource interface {
+	// IDToken returns the raw, encoded identity token.
+	IDToken(ctx context.Context) (string, error)
+}
+
+// FulcioClient requests a short-lived signing certificate bound to the identity asserted
+// by an OIDC token, in the style of sigstore's Fulcio.
+type FulcioClient interface {
+	// RequestCertificate exchanges oidcToken and the public key it is bound to for a
+	// short-lived PEM encoded signing certificate chain.
+	RequestCertificate(ctx context.Context, oidcToken string, publicKeyDER []byte) (certPEM string, err error)
+}
+
+// RekorClient records a signature in a transparency log, in the style of sigstore's Rekor.
+type RekorClient interface {
+	// UploadEntry records sig and returns the resulting transparency log entry.
+	UploadEntry(ctx context.Context, sig Signature) (*TransparencyLogEntry, error)
+}
+
+// keylessSigner signs manifest digests with an ephemeral key pair bound to an OIDC
+// identity via a short-lived Fulcio-style certificate, and records the signature in a
+// Rekor-style transparency log.
+type keylessSigner struct {
+	tokenSource IDTokenSource
+	fulcio      FulcioClient
+	rekor       RekorClient
+}
+
+// NewKeylessSigner creates a Signer that authenticates the signer's identity with an OIDC
+// identity token obtained from tokenSource, rather than a long-lived private key.
+func NewKeylessSigner(tokenSource IDTokenSource, fulcio FulcioClient, rekor RekorClient) Signer {
+	return &keylessSigner{
+		tokenSource: tokenSource,
+		fulcio:      fulcio,
+		rekor:       rekor,
+	}
+}
+
+func (s *keylessSigner) Algorithm() string {
+	return keylessAlgorithm
+}
+
+func (s *keylessSigner) Sign(ctx context.Context, manifestDigest string) (Signature, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return Signature{}, fmt.Errorf("unable to generate ephemeral signing key: %w", err)
+	}
+
+	idToken, err := s.tokenSource.IDToken(ctx)
+	if err != nil {
+		return Signature{}, fmt.Errorf("unable to obtain oidc identity token: %w", err)
+	}
+
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return Signature{}, fmt.Errorf("unable to marshal ephemeral public key: %w", err)
+	}
+
+	certPEM, err := s.fulcio.RequestCertificate(ctx, idToken, pubDER)
+	if err != nil {
+		return Signature{}, fmt.Errorf("unable to obtain signing certificate: %w", err)
+	}
+
+	rawSig, err := ecdsa.SignASN1(rand.Reader, key, []byte(manifestDigest))
+	if err != nil {
+		return Signature{}, fmt.Errorf("unable to sign manifest digest: %w", err)
+	}
+
+	sig := Signature{
+		ManifestDigest: manifestDigest,
+		Algorithm:      keylessAlgorithm,
+		Signature:      base64.StdEncoding.EncodeToString(rawSig),
+		Certificate:    certPEM,
+	}
+
+	entry, err := s.rekor.UploadEntry(ctx, sig)
+	if err != nil {
+		return Signature{}, fmt.Errorf("unable to record signature in transparency log: %w", err)
+	}
+	sig.Bundle = entry
+
+	return sig, nil
+}
+
+// certificateIdentity returns the issuer and subject asserted by a keyless signature's
+// certificate, as used to evaluate an IdentityPolicy during verification.
+func certificateIdentity(certPEM string) (issuer string, subject string, err error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return "", "", fmt.Errorf("unable to decode signing certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to parse signing certificate: %w", err)
+	}
+	if len(cert.EmailAddresses) == 0 {
+		return cert.Issuer.String(), cert.Subject.String(), nil
+	}
+	return cert.Issuer.String(), cert.EmailAddresses[0], nil
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package signing signs and verifies component descriptor oci manifests, either with a
+// user supplied key pair or keylessly via a short-lived, OIDC identity bound certificate.
+package signing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+// SignatureMediaType is the media type of the layer holding the signature payload of a
+// signed component descriptor manifest.
+const SignatureMediaType = "application/vnd.gardener.component.signature.v1+json"
+
+// SignatureArtifactSuffix is appended to the algorithm-prefixed digest of a component
+// descriptor manifest to form the tag of the sibling manifest holding its signature, e.g.
+// "sha256-<digest>.sig".
+const SignatureArtifactSuffix = ".sig"
+
+// EmptyConfigMediaType is the media type of the placeholder config blob of a signature
+// artifact manifest, which carries no information of its own.
+const EmptyConfigMediaType = "application/vnd.oci.empty.v1+json"
+
+// EmptyConfig is the fixed content of a signature artifact's config blob.
+const EmptyConfig = "{}"
+
+// ArtifactTag returns the tag of the signature artifact manifest sibling to a component
+// descriptor manifest with the given digest, following the cosign convention of
+// "sha256-<digest>.sig".
+func ArtifactTag(manifestDigest digest.Digest) string {
+	return fmt.Sprintf("%s-%s%s", manifestDigest.Algorithm(), manifestDigest.Encoded(), SignatureArtifactSuffix)
+}
+
+// Signature is the payload stored as the single layer of a signature artifact. It binds
+// the signature to the digest of the component descriptor manifest it signs.
+type Signature struct {
+	// ManifestDigest is the digest of the component descriptor manifest being signed.
+	ManifestDigest string `json:"manifestDigest"`
+	// Algorithm identifies the signing algorithm, e.g. "ecdsa-p256", "ed25519" or "fulcio-keyless".
+	Algorithm string `json:"algorithm"`
+	// Signature is the base64 encoded raw signature bytes.
+	Signature string `json:"signature"`
+	// Certificate is the PEM encoded short-lived signing certificate, only set for keyless signatures.
+	Certificate string `json:"certificate,omitempty"`
+	// Bundle references the transparency log entry covering this signature, only set for
+	// keyless signatures.
+	Bundle *TransparencyLogEntry `json:"bundle,omitempty"`
+}
+
+// TransparencyLogEntry references an entry in a Rekor-style transparency log.
+type TransparencyLogEntry struct {
+	// LogIndex is the index of the entry in the transparency log.
+	LogIndex int64 `json:"logIndex"`
+	// LogID identifies the transparency log instance the entry was written to.
+	LogID string `json:"logID"`
+	// IntegratedTime is the time at which the entry was integrated into the log.
+	IntegratedTime time.Time `json:"integratedTime"`
+}
+
+// Signer signs the digest of a component descriptor manifest.
+type Signer interface {
+	// Algorithm returns the value to store in Signature.Algorithm.
+	Algorithm() string
+	// Sign signs manifestDigest and returns the resulting Signature.
+	Sign(ctx context.Context, manifestDigest string) (Signature, error)
+}
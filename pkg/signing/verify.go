@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+)
+
+// IdentityPolicy constrains which keyless signer identities are accepted during
+// verification of a keyless signature.
+type IdentityPolicy struct {
+	// Issuer is the expected OIDC issuer of the signing certificate.
+	Issuer string
+	// SubjectRegexp matches the expected subject (e.g. an email address) of the signing certificate.
+	SubjectRegexp *regexp.Regexp
+}
+
+// VerifyWithKey verifies sig against manifestDigest using the PEM encoded public key
+// publicKeyPEM. It returns an error if the signature is invalid or does not match digest.
+func VerifyWithKey(sig Signature, publicKeyPEM []byte, manifestDigest string) error {
+	if sig.ManifestDigest != manifestDigest {
+		return fmt.Errorf("signature covers digest %q, expected %q", sig.ManifestDigest, manifestDigest)
+	}
+
+	block, _ := pem.Decode(publicKeyPEM)
+	if block == nil {
+		return fmt.Errorf("unable to decode public key pem block")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("unable to parse public key: %w", err)
+	}
+
+	rawSig, err := base64.StdEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		return fmt.Errorf("unable to decode signature: %w", err)
+	}
+
+	switch key := pub.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, []byte(manifestDigest), rawSig) {
+			return fmt.Errorf("signature verification failed")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, []byte(manifestDigest), rawSig) {
+			return fmt.Errorf("signature verification failed")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T, expected ecdsa or ed25519", pub)
+	}
+
+	return nil
+}
+
+// VerifyWithIdentityPolicy verifies sig against manifestDigest and checks that the
+// identity asserted by its keyless signing certificate satisfies policy.
+func VerifyWithIdentityPolicy(sig Signature, policy IdentityPolicy, manifestDigest string) error {
+	if sig.Algorithm != keylessAlgorithm || len(sig.Certificate) == 0 {
+		return fmt.Errorf("signature is not a keyless signature")
+	}
+	if sig.ManifestDigest != manifestDigest {
+		return fmt.Errorf("signature covers digest %q, expected %q", sig.ManifestDigest, manifestDigest)
+	}
+
+	issuer, subject, err := certificateIdentity(sig.Certificate)
+	if err != nil {
+		return err
+	}
+	if policy.Issuer != "" && issuer != policy.Issuer {
+		return fmt.Errorf("signing certificate issuer %q does not match required issuer %q", issuer, policy.Issuer)
+	}
+	if policy.SubjectRegexp != nil && !policy.SubjectRegexp.MatchString(subject) {
+		return fmt.Errorf("signing certificate subject %q does not match required pattern %q", subject, policy.SubjectRegexp.String())
+	}
+
+	return nil
+}
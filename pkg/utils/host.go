@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ParseHostFromBaseURL returns the host (and optional port) of an oci registry base url.
+// The base url may or may not include a scheme.
+func ParseHostFromBaseURL(baseURL string) (string, error) {
+	if len(baseURL) == 0 {
+		return "", fmt.Errorf("base url must not be empty")
+	}
+
+	raw := baseURL
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse base url %q: %w", baseURL, err)
+	}
+	if len(u.Host) == 0 {
+		return "", fmt.Errorf("unable to determine host from base url %q", baseURL)
+	}
+
+	return u.Host, nil
+}